@@ -0,0 +1,209 @@
+package routerosclient
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	changeCreate = "create"
+	changeUpdate = "update"
+	changeDelete = "delete"
+)
+
+// change is a successfully applied step of a ChangeSet.
+// res is the resource as it is after the change, prior is the resource as it
+// was before the change (nil for created resources).
+type change struct {
+	op    string
+	res   Resource
+	prior Resource
+}
+
+// ChangeSet applies changes one by one and records every successful step
+// along with the prior state of the resource. If a step fails, all applied
+// steps are reverted in reverse order.
+type ChangeSet struct {
+	c          *Client
+	changes    []change
+	rolledBack bool
+}
+
+// RollbackError is returned when a ChangeSet has been rolled back.
+// Err is the error which caused the rollback, Rollback contains errors
+// occurred while reverting applied changes, if any.
+type RollbackError struct {
+	Err      error
+	Rollback []error
+}
+
+func (e *RollbackError) Error() string {
+	if len(e.Rollback) == 0 {
+		return fmt.Sprintf("changes have been rolled back: %v", e.Err)
+	}
+
+	errs := make([]string, len(e.Rollback))
+	for i, err := range e.Rollback {
+		errs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%v; rollback failed: %v", e.Err, strings.Join(errs, "; "))
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// NewChangeSet returns an empty change set bound to the client.
+func (c *Client) NewChangeSet() *ChangeSet {
+	return &ChangeSet{c: c}
+}
+
+// Transaction runs fn with a new change set. If fn returns an error,
+// changes applied so far are rolled back and *RollbackError is returned.
+func (c *Client) Transaction(fn func(cs *ChangeSet) error) error {
+	cs := c.NewChangeSet()
+
+	if err := fn(cs); err != nil {
+		var rerr *RollbackError
+		if errors.As(err, &rerr) {
+			return err
+		}
+
+		return cs.fail(err)
+	}
+
+	cs.Commit()
+
+	return nil
+}
+
+// CreateResource creates resource and records the change.
+func (cs *ChangeSet) CreateResource(res Resource) (string, error) {
+	if cs.rolledBack {
+		return "", fmt.Errorf("change set has been rolled back")
+	}
+
+	id, err := cs.c.CreateResource(res)
+	if err != nil {
+		return "", cs.fail(err)
+	}
+
+	created := cloneResource(res)
+	created.setID(id)
+	cs.changes = append(cs.changes, change{op: changeCreate, res: created})
+
+	return id, nil
+}
+
+// UpdateResource updates resource o with n and records the change.
+func (cs *ChangeSet) UpdateResource(o Resource, n Resource) (error, bool) {
+	if cs.rolledBack {
+		return fmt.Errorf("change set has been rolled back"), false
+	}
+
+	prior, err := cs.c.ReadResource(o)
+	if err != nil {
+		return cs.fail(err), false
+	}
+
+	if err, ok := cs.c.UpdateResource(o, n); !ok {
+		return cs.fail(err), false
+	}
+
+	cs.changes = append(cs.changes, change{op: changeUpdate, res: cloneResource(n), prior: prior})
+
+	return nil, true
+}
+
+// DeleteResource deletes resource and records the change.
+func (cs *ChangeSet) DeleteResource(res Resource) (error, bool) {
+	if cs.rolledBack {
+		return fmt.Errorf("change set has been rolled back"), false
+	}
+
+	prior, err := cs.c.ReadResource(res)
+	if err != nil {
+		return cs.fail(err), false
+	}
+
+	if err, ok := cs.c.DeleteResource(prior); !ok {
+		return cs.fail(err), false
+	}
+
+	cs.changes = append(cs.changes, change{op: changeDelete, prior: prior})
+
+	return nil, true
+}
+
+// Commit forgets recorded changes, so they can't be rolled back anymore.
+func (cs *ChangeSet) Commit() {
+	cs.changes = nil
+}
+
+// Rollback reverts applied changes in reverse order. It doesn't stop on
+// the first failure and returns errors of all steps which couldn't be reverted.
+func (cs *ChangeSet) Rollback() []error {
+	var errs []error
+
+	// re-created resources get new ids, so the following steps
+	// referring to old ones must be adjusted
+	ids := make(map[string]string)
+	remap := func(r Resource) Resource {
+		r = cloneResource(r)
+		if id, ok := ids[r.getID()]; ok {
+			r.setID(id)
+		}
+		return r
+	}
+
+	for i := len(cs.changes) - 1; i >= 0; i-- {
+		ch := cs.changes[i]
+		log.Printf("[D][T] rollback %v: %v", ch.op, ch.res)
+
+		switch ch.op {
+		case changeCreate:
+			if err, ok := cs.c.DeleteResource(remap(ch.res)); !ok {
+				errs = append(errs, fmt.Errorf("unable to revert %v of %v: %v", ch.op, ch.res, err))
+			}
+		case changeUpdate:
+			res, prior := remap(ch.res), remap(ch.prior)
+
+			if err, ok := cs.c.UpdateResource(res, prior); !ok {
+				errs = append(errs, fmt.Errorf("unable to revert %v of %v: %v", ch.op, ch.res, err))
+				continue
+			}
+
+			// attributes empty before the update are not sent by set
+			if err := cs.c.unsetAttrs(res, prior); err != nil {
+				errs = append(errs, fmt.Errorf("unable to revert %v of %v: %v", ch.op, ch.res, err))
+			}
+		case changeDelete:
+			r := cloneResource(ch.prior)
+			r.setID("")
+
+			id, err := cs.c.CreateResource(r)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to revert %v of %v: %v", ch.op, ch.prior, err))
+				continue
+			}
+			ids[ch.prior.getID()] = id
+		}
+	}
+
+	cs.changes = nil
+	cs.rolledBack = true
+
+	return errs
+}
+
+func (cs *ChangeSet) fail(err error) error {
+	log.Printf("[E][T] change failed, rolling back: %v", err)
+
+	return &RollbackError{
+		Err:      err,
+		Rollback: cs.Rollback(),
+	}
+}
@@ -0,0 +1,172 @@
+package routerosclient
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-routeros/routeros"
+)
+
+func TestChangeSetRollback(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 8)}
+	s := &scenario{conn: conn}
+	c := &Client{conn: conn}
+
+	server := &ResourceDHCPServer{
+		Interface: "ether1",
+		Name:      "dhcp1",
+	}
+	network := &ResourceDHCPServerNetwork{
+		Address: "192.168.0.0/24",
+	}
+
+	// server is created, network exists already
+	s.ResourceDoesNotExist()
+	s.ResourceCreated()
+	s.ResourceExists()
+	// rollback: server is deleted
+	s.ResourceExists()
+	s.ResourceDeleted()
+
+	cs := c.NewChangeSet()
+
+	if _, err := cs.CreateResource(server); err != nil {
+		t.Fatalf("expected resource created, got error: %v", err)
+	}
+
+	_, err := cs.CreateResource(network)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	rerr, ok := err.(*RollbackError)
+	if !ok {
+		t.Fatalf("expected RollbackError, got: %v", err)
+	}
+	if len(rerr.Rollback) != 0 {
+		t.Errorf("expected rollback succeeded, got errors: %v", rerr.Rollback)
+	}
+
+	if len(conn.q) != 0 {
+		t.Errorf("expected all replies consumed, %v left", len(conn.q))
+	}
+
+	if _, err := cs.CreateResource(network); err == nil {
+		t.Errorf("expected error on rolled back change set, got nil")
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 8)}
+	s := &scenario{conn: conn}
+	c := &Client{conn: conn}
+
+	o := &ResourceDNSStaticRecord{
+		Address: "169.254.169.254",
+		Name:    "host.example.tld",
+	}
+	n := &ResourceDNSStaticRecord{
+		Address: "169.254.169.253",
+		Name:    "host.example.tld",
+	}
+	failure := errors.New("provisioning failed")
+
+	// update: read prior state, read and set
+	conn.buildReply([]map[string]string{{".id": "*1", "address": "169.254.169.254", "name": "host.example.tld"}}, nil)
+	s.ResourceExists()
+	s.ResourceUpdated()
+	// rollback: read and set prior state
+	s.ResourceExists()
+	s.ResourceUpdated()
+
+	err := c.Transaction(func(cs *ChangeSet) error {
+		if err, ok := cs.UpdateResource(o, n); !ok {
+			return err
+		}
+
+		return failure
+	})
+
+	if !errors.Is(err, failure) {
+		t.Fatalf("expected original error, got: %v", err)
+	}
+
+	var rerr *RollbackError
+	if !errors.As(err, &rerr) || len(rerr.Rollback) != 0 {
+		t.Errorf("expected rollback succeeded, got errors: %v", rerr.Rollback)
+	}
+
+	if len(conn.q) != 0 {
+		t.Errorf("expected all replies consumed, %v left", len(conn.q))
+	}
+}
+
+func TestTransactionWrappedRollbackError(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 8)}
+	s := &scenario{conn: conn}
+	c := &Client{conn: conn}
+
+	// record exists already, nothing to roll back
+	s.ResourceExists()
+
+	err := c.Transaction(func(cs *ChangeSet) error {
+		_, err := cs.CreateResource(&ResourceDNSStaticRecord{Address: "169.254.169.254", Name: "host.example.tld"})
+		return fmt.Errorf("provisioning: %w", err)
+	})
+
+	var rerr *RollbackError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("expected RollbackError, got: %v", err)
+	}
+
+	if errors.As(rerr.Err, new(*RollbackError)) {
+		t.Errorf("expected RollbackError not wrapped twice, got: %v", err)
+	}
+}
+
+func TestRollbackUnsetsAttributes(t *testing.T) {
+	f := newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		if words[0] == "/ip/dns/static/print" {
+			r.Re(map[string]string{".id": "*1", "address": "169.254.169.254", "name": "host.example.tld", "disabled": "false"})
+		}
+		r.Done(nil)
+	})
+	c := getFakeClient(t, f)
+
+	o := &ResourceDNSStaticRecord{Address: "169.254.169.254", Name: "host.example.tld"}
+	n := &ResourceDNSStaticRecord{Address: "169.254.169.254", Name: "host.example.tld", Comment: "edge", TTL: "1d"}
+	failure := errors.New("provisioning failed")
+
+	err := c.Transaction(func(cs *ChangeSet) error {
+		if err, ok := cs.UpdateResource(o, n); !ok {
+			return err
+		}
+
+		return failure
+	})
+
+	var rerr *RollbackError
+	if !errors.As(err, &rerr) || len(rerr.Rollback) != 0 {
+		t.Fatalf("expected rollback succeeded, got: %v", err)
+	}
+
+	// words of unset sentences come in any order
+	var unset []string
+	for _, words := range f.Sentences() {
+		if words[0] != "/ip/dns/static/unset" {
+			continue
+		}
+
+		sort.Strings(words[1:])
+		unset = append(unset, strings.Join(words[1:], " "))
+	}
+
+	expected := []string{"=numbers=*1 =value-name=comment", "=numbers=*1 =value-name=ttl"}
+	if !reflect.DeepEqual(unset, expected) {
+		t.Errorf("expected %v, got %v", expected, unset)
+	}
+}
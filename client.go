@@ -3,7 +3,10 @@ package routerosclient
 import (
 	"crypto/tls"
 	"fmt"
+	"log"
 	"net"
+	"net/netip"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -15,12 +18,41 @@ type TLSConfig struct {
 	conf *tls.Config
 }
 
+// NewTLSConfig returns TLS configuration used by the TLS API and REST API
+// connections, e.g. with custom RootCAs or client certificates. The conf is
+// cloned on every connection, nil conf means defaults.
+func NewTLSConfig(conf *tls.Config) *TLSConfig {
+	return &TLSConfig{conf: conf}
+}
+
+const (
+	defaultPort     = 8728
+	defaultTLSPort  = 8729
+	defaultRESTPort = 443
+)
+
+// Dialer establishes network connections to RouterOS. It's satisfied by
+// *net.Dialer and by proxy dialers like golang.org/x/net/proxy.
+type Dialer interface {
+	Dial(network, address string) (net.Conn, error)
+}
+
+// Config is a client configuration. Address is a hostname, an IPv4 address
+// or an IPv6 address (bracketed, if followed by a port), with an optional
+// port. When port is omitted, the default one of the API (8728), the TLS API
+// (8729) or the REST API (443) is used.
+//
+// Credentials are taken from Username and Password, unless Credentials
+// provider is set.
 type Config struct {
-	Address   string     `valid:"-"`
-	Username  string     `valid:"required"`
-	Password  string     `valid:"required"`
-	Async     bool       `valid:"optional"`
-	TLSConfig *TLSConfig `valid:"optional"`
+	Address     string             `valid:"-"`
+	Username    string             `valid:"optional"`
+	Password    string             `valid:"optional"`
+	Credentials CredentialProvider `valid:"-"`
+	Async       bool               `valid:"optional"`
+	TLSConfig   *TLSConfig         `valid:"optional"`
+	REST        bool               `valid:"optional"`
+	Dialer      Dialer             `valid:"-"`
 }
 
 type Conn interface {
@@ -29,8 +61,11 @@ type Conn interface {
 }
 
 type Client struct {
-	mu   sync.Mutex
-	conn Conn
+	mu       sync.Mutex
+	conn     Conn
+	config   *Config
+	safeMode bool
+	version  Version
 }
 
 func NewClient(c *Config) (*Client, error) {
@@ -39,7 +74,9 @@ func NewClient(c *Config) (*Client, error) {
 		return nil, err
 	}
 
-	if c.TLSConfig != nil {
+	if c.REST {
+		return NewRESTClient(c)
+	} else if c.TLSConfig != nil {
 		return NewTLSClient(c)
 	} else {
 		return NewInsecureClient(c)
@@ -47,29 +84,124 @@ func NewClient(c *Config) (*Client, error) {
 }
 
 func NewInsecureClient(c *Config) (*Client, error) {
-	rc, err := routeros.Dial(c.Address, c.Username, c.Password)
+	return c.newClient(c.connectInsecure)
+}
+
+func NewTLSClient(c *Config) (*Client, error) {
+	return c.newClient(c.connectTLS)
+}
+
+// newClient returns a client connected by connect, which is kept
+// for reconnecting.
+func (c *Config) newClient(connect func() (Conn, error)) (*Client, error) {
+	conn, err := connect()
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		conn: rc,
-	}, nil
+	client := newClient(conn)
+	client.config = c
+
+	return client, nil
 }
 
-func NewTLSClient(c *Config) (*Client, error) {
-	// TODO: validate tls config
-	// rc, err := routeros.DialTLS(c.address, c.username, c.password, c.tlsConfig.conf)
-	rc, err := routeros.DialTLS(c.Address, c.Username, c.Password, nil)
+// connect connects to RouterOS according to the config.
+func (c *Config) connect() (Conn, error) {
+	if c.REST {
+		return c.connectREST()
+	} else if c.TLSConfig != nil {
+		return c.connectTLS()
+	} else {
+		return c.connectInsecure()
+	}
+}
+
+func (c *Config) connectInsecure() (Conn, error) {
+	conn, err := c.dial()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.login(conn)
+}
+
+func (c *Config) connectTLS() (Conn, error) {
+	conn, err := c.dial()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var conf *tls.Config
+	if c.TLSConfig != nil && c.TLSConfig.conf != nil {
+		conf = c.TLSConfig.conf.Clone()
+	} else {
+		conf = &tls.Config{}
+	}
+
+	if conf.ServerName == "" {
+		conf.ServerName, _, _ = net.SplitHostPort(c.Address)
+	}
+
+	return c.login(tls.Client(conn, conf))
+}
+
+// dial connects to RouterOS using the configured dialer.
+func (c *Config) dial() (net.Conn, error) {
+	if c.Dialer == nil {
+		return net.Dial("tcp", c.Address)
+	}
+
+	return c.Dialer.Dial("tcp", c.Address)
+}
+
+// login logs in to RouterOS over the connection using credentials
+// obtained from the credential provider.
+func (c *Config) login(conn net.Conn) (Conn, error) {
+	username, password, err := c.credentials().Credentials(c.Address)
+
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to get credentials: %v", err)
+	}
+
+	rc, err := routeros.NewClient(conn)
 
 	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := rc.Login(username, password); err != nil {
+		rc.Close()
 		return nil, err
 	}
 
-	return &Client{
-		conn: rc,
-	}, nil
+	return rc, nil
+}
+
+// credentials returns the credential provider of the config.
+func (c *Config) credentials() CredentialProvider {
+	if c.Credentials != nil {
+		return c.Credentials
+	}
+
+	return StaticCredentials{Username: c.Username, Password: c.Password}
+}
+
+// newClient returns a client using the connection and detects RouterOS version.
+func newClient(conn Conn) *Client {
+	c := &Client{
+		conn: conn,
+	}
+
+	if err := c.detectVersion(); err != nil {
+		log.Printf("[W] unable to detect RouterOS version, version checks are disabled: %v", err)
+	}
+
+	return c
 }
 
 func (c *Client) Run(query string) (*routeros.Reply, error) {
@@ -83,29 +215,90 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
+// Reconnect replaces the connection with a new one, obtaining credentials
+// again, so rotated passwords are picked up. Safe mode is left, since
+// RouterOS reverts changes of the dropped session. Version is detected
+// again, as RouterOS may have been upgraded meanwhile.
+func (c *Client) Reconnect() error {
+	if c.config == nil {
+		return fmt.Errorf("client has no config to reconnect with")
+	}
+
+	conn, err := c.config.connect()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	old := c.conn
+	c.conn = conn
+	c.safeMode = false
+	c.mu.Unlock()
+
+	old.Close()
+
+	if err := c.detectVersion(); err != nil {
+		log.Printf("[W] unable to detect RouterOS version, version checks are disabled: %v", err)
+		c.version = Version{}
+	}
+
+	return nil
+}
+
 func (c *Config) validate() error {
 
 	if _, err := govalidator.ValidateStruct(c); err != nil {
 		return err
 	}
 
-	host, port, err := net.SplitHostPort(c.Address)
+	if err := c.normalizeAddress(); err != nil {
+		return err
+	}
 
-	if err != nil {
-		return fmt.Errorf("unable to parse RouterOS address")
+	if c.Credentials == nil && (c.Username == "" || c.Password == "") {
+		return fmt.Errorf("username and password are required")
 	}
 
-	if !govalidator.IsIPv4(host) {
-		return fmt.Errorf("invalid IPv4 address")
+	return nil
+}
+
+// normalizeAddress validates Address and fills in the default port.
+func (c *Config) normalizeAddress() error {
+	host, port := c.Address, ""
+
+	if _, err := netip.ParseAddr(host); err == nil {
+		// bare IP address
+	} else if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	} else if strings.Contains(host, ":") {
+		h, p, err := net.SplitHostPort(host)
+		if err != nil {
+			return fmt.Errorf("unable to parse RouterOS address")
+		}
+
+		host, port = h, p
+	}
+
+	if _, err := netip.ParseAddr(host); err != nil && !govalidator.IsDNSName(host) {
+		return fmt.Errorf("invalid host")
+	}
+
+	if port == "" {
+		switch {
+		case c.REST:
+			port = strconv.Itoa(defaultRESTPort)
+		case c.TLSConfig != nil:
+			port = strconv.Itoa(defaultTLSPort)
+		default:
+			port = strconv.Itoa(defaultPort)
+		}
 	}
 
 	if !govalidator.IsPort(port) {
 		return fmt.Errorf("invalid port")
 	}
 
-	if c.Username == "" || c.Password == "" {
-		return fmt.Errorf("username and password are required")
-	}
+	c.Address = net.JoinHostPort(host, port)
 
 	return nil
 }
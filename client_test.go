@@ -1,6 +1,9 @@
 package routerosclient
 
-import "testing"
+import (
+	"net"
+	"testing"
+)
 
 func TestConfigValidate(t *testing.T) {
 	conf := Config{
@@ -13,3 +16,78 @@ func TestConfigValidate(t *testing.T) {
 		t.Errorf("invalid Config must raise error")
 	}
 }
+
+func TestConfigValidateAddress(t *testing.T) {
+	cases := []struct {
+		conf     Config
+		expected string
+	}{
+		{Config{Address: "192.168.88.1"}, "192.168.88.1:8728"},
+		{Config{Address: "192.168.88.1:18728"}, "192.168.88.1:18728"},
+		{Config{Address: "router.lan"}, "router.lan:8728"},
+		{Config{Address: "router.lan", TLSConfig: &TLSConfig{}}, "router.lan:8729"},
+		{Config{Address: "router.lan", REST: true}, "router.lan:443"},
+		{Config{Address: "fe80::1"}, "[fe80::1]:8728"},
+		{Config{Address: "[2001:db8::1]"}, "[2001:db8::1]:8728"},
+		{Config{Address: "[2001:db8::1]:8729"}, "[2001:db8::1]:8729"},
+		{Config{Address: "router lan"}, ""},
+		{Config{Address: "[router.lan:8728"}, ""},
+		{Config{Address: "router.lan:65536"}, ""},
+		{Config{Address: ""}, ""},
+	}
+
+	for _, c := range cases {
+		conf := c.conf
+		conf.Username, conf.Password = "vagrant", "vagrant"
+
+		err := conf.validate()
+
+		if c.expected == "" {
+			if err == nil {
+				t.Errorf("expected error for %q, got %q", c.conf.Address, conf.Address)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", c.conf.Address, err)
+		} else if conf.Address != c.expected {
+			t.Errorf("expected %q, got %q", c.expected, conf.Address)
+		}
+	}
+}
+
+type testDialer struct {
+	addrs []string
+}
+
+func (d *testDialer) Dial(network, address string) (net.Conn, error) {
+	d.addrs = append(d.addrs, address)
+	return net.Dial(network, address)
+}
+
+func TestNewClientDialer(t *testing.T) {
+	f := newFakeRouterOS(t, func(r *FakeReply, words []string) {})
+
+	_, port, _ := net.SplitHostPort(f.Addr())
+	d := &testDialer{}
+
+	c, err := NewClient(&Config{
+		Address:  "localhost:" + port,
+		Username: "admin",
+		Password: "admin",
+		Dialer:   d,
+	})
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+	defer c.Close()
+
+	if len(d.addrs) != 1 || d.addrs[0] != "localhost:"+port {
+		t.Errorf("expected dialer used for localhost:%v, got %v", port, d.addrs)
+	}
+
+	if c.Version().IsZero() {
+		t.Errorf("expected version detected over dialed connection")
+	}
+}
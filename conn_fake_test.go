@@ -0,0 +1,217 @@
+package routerosclient
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-routeros/routeros/proto"
+)
+
+// FakeRouterOS is a minimal RouterOS API server speaking the wire protocol.
+// Every sentence except /login and /system/resource/print is recorded
+// and passed to the handler.
+type FakeRouterOS struct {
+	Version  string // reported by /system/resource/print
+	Password string // if set, /login with other passwords fails
+	ln       net.Listener
+	handler  func(r *FakeReply, words []string)
+	mu       sync.Mutex
+	sentence [][]string
+}
+
+// FakeReply writes reply sentences tagged with the tag of the request.
+type FakeReply struct {
+	mu  *sync.Mutex
+	w   proto.Writer
+	tag string
+}
+
+func newFakeRouterOS(t *testing.T, handler func(r *FakeReply, words []string)) *FakeRouterOS {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %v", err)
+	}
+
+	f := &FakeRouterOS{Version: "7.12.1 (stable)", ln: ln, handler: handler}
+	go f.serve()
+	t.Cleanup(func() { ln.Close() })
+
+	return f
+}
+
+func (f *FakeRouterOS) Addr() string {
+	return f.ln.Addr().String()
+}
+
+// Commands returns command words of received sentences.
+func (f *FakeRouterOS) Commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cmds := make([]string, len(f.sentence))
+	for i, s := range f.sentence {
+		cmds[i] = s[0]
+	}
+
+	return cmds
+}
+
+// Sentences returns received sentences.
+func (f *FakeRouterOS) Sentences() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([][]string(nil), f.sentence...)
+}
+
+func (f *FakeRouterOS) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *FakeRouterOS) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	wmu := &sync.Mutex{}
+	w := proto.NewWriter(conn)
+
+	for {
+		words, err := readFakeSentence(r)
+		if err != nil {
+			return
+		}
+
+		reply := &FakeReply{mu: wmu, w: w}
+		for _, word := range words {
+			if strings.HasPrefix(word, ".tag=") {
+				reply.tag = strings.TrimPrefix(word, ".tag=")
+			}
+		}
+
+		switch words[0] {
+		case "/login":
+			if f.Password != "" && words[len(words)-1] != "=password="+f.Password {
+				reply.Trap("invalid user name or password (6)")
+				continue
+			}
+			reply.Done(nil)
+			continue
+		case "/system/resource/print":
+			reply.Re(map[string]string{"version": f.Version})
+			reply.Done(nil)
+			continue
+		}
+
+		f.mu.Lock()
+		f.sentence = append(f.sentence, words)
+		f.mu.Unlock()
+
+		if f.handler == nil {
+			reply.Done(nil)
+			continue
+		}
+
+		f.handler(reply, words)
+	}
+}
+
+func (r *FakeReply) write(word string, attrs map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.w.BeginSentence()
+	r.w.WriteWord(word)
+	for k, v := range attrs {
+		r.w.WriteWord("=" + k + "=" + v)
+	}
+	if r.tag != "" {
+		r.w.WriteWord(".tag=" + r.tag)
+	}
+	r.w.EndSentence()
+}
+
+func (r *FakeReply) Re(attrs map[string]string) {
+	r.write("!re", attrs)
+}
+
+func (r *FakeReply) Done(attrs map[string]string) {
+	r.write("!done", attrs)
+}
+
+func (r *FakeReply) Trap(message string) {
+	r.write("!trap", map[string]string{"message": message})
+	r.write("!done", nil)
+}
+
+// Interrupted finishes the reply as RouterOS does on /cancel.
+func (r *FakeReply) Interrupted() {
+	r.write("!trap", map[string]string{"category": "2", "message": "interrupted"})
+	r.write("!done", nil)
+}
+
+// readFakeSentence reads a sentence as a list of words. proto.Reader can't be
+// used here, since it doesn't accept query words sent by clients.
+func readFakeSentence(r *bufio.Reader) ([]string, error) {
+	var words []string
+
+	for {
+		l, err := readFakeLength(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if l == 0 {
+			if len(words) == 0 {
+				continue
+			}
+			return words, nil
+		}
+
+		b := make([]byte, l)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+		words = append(words, string(b))
+	}
+}
+
+func readFakeLength(r *bufio.Reader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var n, extra int
+	switch {
+	case b&0x80 == 0x00:
+		return int(b), nil
+	case b&0xC0 == 0x80:
+		n, extra = int(b&^0xC0), 1
+	case b&0xE0 == 0xC0:
+		n, extra = int(b&^0xE0), 2
+	case b&0xF0 == 0xE0:
+		n, extra = int(b&^0xF0), 3
+	default:
+		n, extra = 0, 4
+	}
+
+	for i := 0; i < extra; i++ {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		n = n<<8 | int(c)
+	}
+
+	return n, nil
+}
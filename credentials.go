@@ -0,0 +1,210 @@
+package routerosclient
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+const (
+	defaultUsernameEnv = "ROUTEROS_USERNAME"
+	defaultPasswordEnv = "ROUTEROS_PASSWORD"
+)
+
+// CredentialProvider provides credentials for a router. It's consulted every
+// time a connection is established, so credentials may be rotated without
+// rebuilding clients. Address is the router address in host:port form.
+type CredentialProvider interface {
+	Credentials(address string) (username, password string, err error)
+}
+
+// StaticCredentials provides fixed credentials.
+type StaticCredentials struct {
+	Username string
+	Password string
+}
+
+// Credentials implements CredentialProvider.
+func (s StaticCredentials) Credentials(address string) (string, string, error) {
+	return s.Username, s.Password, nil
+}
+
+// EnvCredentials provides credentials from environment variables,
+// ROUTEROS_USERNAME and ROUTEROS_PASSWORD by default. Username, if set,
+// is used instead of the username variable.
+type EnvCredentials struct {
+	Username    string
+	UsernameVar string
+	PasswordVar string
+}
+
+// Credentials implements CredentialProvider.
+func (e EnvCredentials) Credentials(address string) (string, string, error) {
+	uv, pv := e.UsernameVar, e.PasswordVar
+
+	if uv == "" {
+		uv = defaultUsernameEnv
+	}
+
+	if pv == "" {
+		pv = defaultPasswordEnv
+	}
+
+	username, password := e.Username, os.Getenv(pv)
+
+	if username == "" {
+		username = os.Getenv(uv)
+	}
+
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("environment variables %v and %v must be set", uv, pv)
+	}
+
+	return username, password, nil
+}
+
+// FileCredentials provides credentials from files, e.g. mounted secrets.
+// Trailing newlines are stripped. Username is used when UsernameFile
+// is empty.
+type FileCredentials struct {
+	Username     string
+	UsernameFile string
+	PasswordFile string
+}
+
+// Credentials implements CredentialProvider.
+func (f FileCredentials) Credentials(address string) (string, string, error) {
+	username := f.Username
+
+	if f.UsernameFile != "" {
+		b, err := os.ReadFile(f.UsernameFile)
+		if err != nil {
+			return "", "", err
+		}
+		username = strings.TrimRight(string(b), "\r\n")
+	}
+
+	b, err := os.ReadFile(f.PasswordFile)
+	if err != nil {
+		return "", "", err
+	}
+	password := strings.TrimRight(string(b), "\r\n")
+
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("username and password are required")
+	}
+
+	return username, password, nil
+}
+
+// NetrcCredentials provides credentials of multiple routers from a file
+// in netrc format:
+//
+//	machine 192.168.88.1 login admin password secret
+//	machine router.lan:8729 login admin password secret
+//	default login admin password secret
+//
+// Machine matches either host or host:port of the address. The file is read
+// on every call.
+type NetrcCredentials struct {
+	Path string
+}
+
+// Credentials implements CredentialProvider.
+func (n NetrcCredentials) Credentials(address string) (string, string, error) {
+	b, err := os.ReadFile(n.Path)
+	if err != nil {
+		return "", "", err
+	}
+
+	entries, err := parseNetrc(string(b))
+	if err != nil {
+		return "", "", fmt.Errorf("%v: %v", n.Path, err)
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+
+	var def *netrcEntry
+
+	for i, e := range entries {
+		switch e.machine {
+		case address:
+			return e.login, e.password, nil
+		case host:
+			if def == nil || def.machine == "" {
+				def = &entries[i]
+			}
+		case "":
+			if def == nil {
+				def = &entries[i]
+			}
+		}
+	}
+
+	if def == nil {
+		return "", "", fmt.Errorf("%v: no credentials for %v", n.Path, address)
+	}
+
+	return def.login, def.password, nil
+}
+
+type netrcEntry struct {
+	machine  string // empty for default entry
+	login    string
+	password string
+}
+
+// parseNetrc parses netrc file content. Macro definitions are skipped.
+func parseNetrc(data string) ([]netrcEntry, error) {
+	var entries []netrcEntry
+	var tokens []string
+
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+
+		switch t {
+		case "machine", "login", "password", "account":
+			if i+1 >= len(tokens) {
+				return nil, fmt.Errorf("missing value of `%v`", t)
+			}
+		}
+
+		switch t {
+		case "machine":
+			i++
+			entries = append(entries, netrcEntry{machine: tokens[i]})
+		case "default":
+			entries = append(entries, netrcEntry{})
+		case "login", "password", "account":
+			if len(entries) == 0 {
+				return nil, fmt.Errorf("`%v` outside of machine", t)
+			}
+			i++
+			switch t {
+			case "login":
+				entries[len(entries)-1].login = tokens[i]
+			case "password":
+				entries[len(entries)-1].password = tokens[i]
+			}
+		case "macdef":
+			// macro body lasts till an empty line, which is lost
+			// by tokenizing, so macros are not supported
+			return nil, fmt.Errorf("macdef is not supported")
+		default:
+			return nil, fmt.Errorf("unexpected token `%v`", t)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,127 @@
+package routerosclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("ROUTEROS_USERNAME", "admin")
+	t.Setenv("ROUTEROS_PASSWORD", "secret")
+	t.Setenv("R1_PASSWORD", "other")
+
+	if u, p, err := (EnvCredentials{}).Credentials("r1:8728"); err != nil || u != "admin" || p != "secret" {
+		t.Errorf("expected default variables used, got %v, %v, %v", u, p, err)
+	}
+
+	if u, p, err := (EnvCredentials{PasswordVar: "R1_PASSWORD"}).Credentials("r1:8728"); err != nil || u != "admin" || p != "other" {
+		t.Errorf("expected R1_PASSWORD used, got %v, %v, %v", u, p, err)
+	}
+
+	if _, _, err := (EnvCredentials{PasswordVar: "R2_PASSWORD"}).Credentials("r2:8728"); err == nil {
+		t.Errorf("expected error for unset variable, got nil")
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "password")
+
+	if err := os.WriteFile(path, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	f := FileCredentials{Username: "admin", PasswordFile: path}
+
+	if u, p, err := f.Credentials("r1:8728"); err != nil || u != "admin" || p != "secret" {
+		t.Errorf("expected credentials read, got %v, %v, %v", u, p, err)
+	}
+
+	if _, _, err := (FileCredentials{Username: "admin", PasswordFile: filepath.Join(dir, "none")}).Credentials("r1:8728"); err == nil {
+		t.Errorf("expected error for missing file, got nil")
+	}
+}
+
+func TestNetrcCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "netrc")
+
+	data := `# routers
+machine 192.168.88.1 login admin password one
+machine router.lan:8729 login api password two
+machine router.lan
+  login admin
+  password three
+default login guest password four
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	n := NetrcCredentials{Path: path}
+
+	cases := map[string][2]string{
+		"192.168.88.1:8728": {"admin", "one"},
+		"router.lan:8729":   {"api", "two"},
+		"router.lan:8728":   {"admin", "three"},
+		"[fe80::1]:8728":    {"guest", "four"},
+	}
+
+	for addr, expected := range cases {
+		u, p, err := n.Credentials(addr)
+		if err != nil || u != expected[0] || p != expected[1] {
+			t.Errorf("%v: expected %v, got %v, %v, %v", addr, expected, u, p, err)
+		}
+	}
+
+	if _, err := parseNetrc("login admin"); err == nil {
+		t.Errorf("expected error for login outside of machine, got nil")
+	}
+}
+
+type rotatingCredentials struct {
+	passwords []string
+	calls     int
+}
+
+func (r *rotatingCredentials) Credentials(address string) (string, string, error) {
+	p := r.passwords[r.calls]
+	r.calls++
+	return "admin", p, nil
+}
+
+func TestClientReconnect(t *testing.T) {
+	f := newFakeRouterOS(t, nil)
+	f.Password = "old"
+
+	creds := &rotatingCredentials{passwords: []string{"old", "new"}}
+
+	c, err := NewClient(&Config{Address: f.Addr(), Credentials: creds})
+	if err != nil {
+		t.Fatalf("expected client, got error: %v", err)
+	}
+	defer c.Close()
+
+	if v := c.Version(); v != mustParseVersion("7.12.1 (stable)") {
+		t.Errorf("expected version detected, got %v", v)
+	}
+
+	f.Password = "new"
+	f.Version = "7.14 (stable)"
+
+	if err := c.Reconnect(); err != nil {
+		t.Fatalf("expected reconnect with rotated password, got error: %v", err)
+	}
+
+	if creds.calls != 2 {
+		t.Errorf("expected credentials obtained on every connect, got %v calls", creds.calls)
+	}
+
+	if v := c.Version(); v != mustParseVersion("7.14 (stable)") {
+		t.Errorf("expected version detected again after reconnect, got %v", v)
+	}
+
+	if _, err := c.Run("/ip/dns/static/print"); err != nil {
+		t.Errorf("expected reconnected client to work, got error: %v", err)
+	}
+}
@@ -1,14 +1,25 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
 package routerosclient
 
 import (
+	"time"
+
 	"github.com/asaskevich/govalidator"
 )
 
 type ResourceDHCPServer struct {
-	ID        string `ros:".id"`
-	Disabled  bool   `ros:"disabled"     valid:"optional"`
-	Name      string `ros:"name"         valid:"optional"`
-	Interface string `ros:"interface"    valid:"required"`
+	ID            string            `ros:".id"`
+	Disabled      bool              `ros:"disabled"      valid:"optional"`
+	Name          string            `ros:"name"          valid:"optional"`
+	Interface     string            `ros:"interface"     valid:"required"`
+	AddARP        *bool             `ros:"add-arp"       valid:"optional"`
+	AddressPool   string            `ros:"address-pool"  valid:"optional"`
+	Authoritative DHCPAuthoritative `ros:"authoritative" valid:"in(yes|no|after-2sec-delay|after-10sec-delay),optional"`
+	BootpSupport  DHCPBootpSupport  `ros:"bootp-support" valid:"in(none|static|dynamic),optional"`
+	LeaseScript   string            `ros:"lease-script"  valid:"optional"`
+	LeaseTime     time.Duration     `ros:"lease-time"    valid:"optional"`
+	Relay         string            `ros:"relay"         valid:"ipv4,optional"`
 }
 
 func (d *ResourceDHCPServer) validate() error {
@@ -32,6 +43,21 @@ func (d *ResourceDHCPServer) setID(id string) {
 	d.ID = id
 }
 
+func (*ResourceDHCPServer) getKeys() []string {
+	return []string{"name"}
+}
+
+func (d *ResourceDHCPServer) getReferences() []Reference {
+	var refs []Reference
+
+	refs = append(refs, newReferences("/interface", "name", d.Interface)...)
+	if d.AddressPool != "static-only" {
+		refs = append(refs, newReferences("/ip/pool", "name", d.AddressPool)...)
+	}
+
+	return refs
+}
+
 func (*ResourceDHCPServer) getCreateCommand() string {
 	return "/ip/dhcp-server/add"
 }
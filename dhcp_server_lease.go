@@ -1,6 +1,10 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
 package routerosclient
 
 import (
+	"strings"
+
 	"github.com/asaskevich/govalidator"
 )
 
@@ -15,15 +19,15 @@ import (
 // BUG: UseSrcMac doesn't use for read query.
 type ResourceDHCPServerLease struct {
 	ID            string `ros:".id"`
-	Address       string `ros:"address"           valid:"ipv4,required"`
-	AddressLists  string `ros:"address-lists"     valid:"optional"`
-	ClientID      string `ros:"client-id"         valid:"optional"`
-	Comment       string `ros:"comment"           valid:"optional"`
-	DHCPOption    string `ros:"dhcp-option"       valid:"optional"`
-	DHCPOptionSet string `ros:"dhcp-option-set"   valid:"optional"`
-	Disabled      bool   `ros:"disabled"          valid:"optional"`
-	MacAddress    string `ros:"mac-address"       valid:"mac,required"`
-	Server        string `ros:"server"            valid:"required"`
+	Address       string `ros:"address"         valid:"ipv4,required"`
+	AddressLists  string `ros:"address-lists"   valid:"optional"`
+	ClientID      string `ros:"client-id"       valid:"optional"`
+	Comment       string `ros:"comment"         valid:"optional"`
+	DHCPOption    string `ros:"dhcp-option"     valid:"optional"`
+	DHCPOptionSet string `ros:"dhcp-option-set" valid:"optional"`
+	Disabled      bool   `ros:"disabled"        valid:"optional"`
+	MacAddress    string `ros:"mac-address"     valid:"mac,required"`
+	Server        string `ros:"server"          valid:"required"`
 }
 
 func (d *ResourceDHCPServerLease) validate() error {
@@ -47,6 +51,22 @@ func (d *ResourceDHCPServerLease) setID(id string) {
 	d.ID = id
 }
 
+func (*ResourceDHCPServerLease) getKeys() []string {
+	return []string{"mac-address"}
+}
+
+func (d *ResourceDHCPServerLease) getReferences() []Reference {
+	var refs []Reference
+
+	if d.Server != "all" {
+		refs = append(refs, newReferences("/ip/dhcp-server", "name", d.Server)...)
+	}
+	refs = append(refs, newReferences("/ip/dhcp-server/option", "name", strings.Split(d.DHCPOption, ",")...)...)
+	refs = append(refs, newReferences("/ip/dhcp-server/option/sets", "name", d.DHCPOptionSet)...)
+
+	return refs
+}
+
 func (*ResourceDHCPServerLease) getCreateCommand() string {
 	return "/ip/dhcp-server/lease/add"
 }
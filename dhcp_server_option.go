@@ -1,24 +1,25 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
 package routerosclient
 
 import (
 	"github.com/asaskevich/govalidator"
 )
 
-/*
- * NOTE: string values must be surrounded by quotes:
- * &ResourceDHCPServerOption{
- *     Code: 66,
- *     Name: "next-server",
- *     Value: "'192.168.0.2'"
- * }
- * For details see: https://wiki.mikrotik.com/wiki/Manual:IP/DHCP_Server#DHCP_Options
- *
- */
+// NOTE: string values must be surrounded by quotes:
+//
+//	&ResourceDHCPServerOption{
+//	    Code: 66,
+//	    Name: "next-server",
+//	    Value: "'192.168.0.2'"
+//	}
+//
+// For details see: https://wiki.mikrotik.com/wiki/Manual:IP/DHCP_Server#DHCP_Options
 type ResourceDHCPServerOption struct {
 	ID    string `ros:".id"`
-	Code  int    `ros:"code"   valid:"required"`
-	Name  string `ros:"name"   valid:"required"`
-	Value string `ros:"value"  valid:"optional"`
+	Code  int    `ros:"code"  valid:"required"`
+	Name  string `ros:"name"  valid:"required"`
+	Value string `ros:"value" valid:"optional"`
 }
 
 func (d *ResourceDHCPServerOption) validate() error {
@@ -42,6 +43,10 @@ func (d *ResourceDHCPServerOption) setID(id string) {
 	d.ID = id
 }
 
+func (*ResourceDHCPServerOption) getKeys() []string {
+	return []string{"name"}
+}
+
 func (*ResourceDHCPServerOption) getCreateCommand() string {
 	return "/ip/dhcp-server/option/add"
 }
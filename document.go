@@ -0,0 +1,281 @@
+package routerosclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a serializable representation of a resource, used in
+// declarative config files. Spec attributes are named after `ros` tags:
+//
+//	kind: DHCPServerLease
+//	spec:
+//	  address: 192.168.0.10
+//	  mac-address: 00:11:22:33:44:55
+//	  server: dhcp1
+//
+// Unset (zero) attributes are omitted, id and read-only attributes are never
+// serialized.
+type Document struct {
+	Kind string
+	Spec Resource
+}
+
+// NewDocuments wraps resources into documents.
+func NewDocuments(res []Resource) []Document {
+	docs := make([]Document, len(res))
+
+	for i, r := range res {
+		docs[i] = Document{Kind: getResourceKind(r), Spec: r}
+	}
+
+	return docs
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Document) MarshalJSON() ([]byte, error) {
+	spec := make(map[string]interface{})
+
+	for _, a := range getSpecAttrs(d.Spec) {
+		spec[a.name] = a.value
+	}
+
+	return json.Marshal(struct {
+		Kind string                 `json:"kind"`
+		Spec map[string]interface{} `json:"spec"`
+	}{d.Kind, spec})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Document) UnmarshalJSON(b []byte) error {
+	var doc struct {
+		Kind string                 `json:"kind"`
+		Spec map[string]interface{} `json:"spec"`
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+
+	return d.decode(doc.Kind, doc.Spec)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Document) MarshalYAML() (interface{}, error) {
+	spec := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, a := range getSpecAttrs(d.Spec) {
+		v := &yaml.Node{}
+		if err := v.Encode(a.value); err != nil {
+			return nil, err
+		}
+
+		spec.Content = append(spec.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: a.name}, v)
+	}
+
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "kind"},
+			{Kind: yaml.ScalarNode, Value: d.Kind},
+			{Kind: yaml.ScalarNode, Value: "spec"},
+			spec,
+		},
+	}, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Document) UnmarshalYAML(value *yaml.Node) error {
+	var doc struct {
+		Kind string                 `yaml:"kind"`
+		Spec map[string]interface{} `yaml:"spec"`
+	}
+
+	if err := value.Decode(&doc); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(value.Content); i += 2 {
+		if k := value.Content[i].Value; k != "kind" && k != "spec" {
+			return fmt.Errorf("line %v: unknown field `%v`", value.Content[i].Line, k)
+		}
+	}
+
+	return d.decode(doc.Kind, doc.Spec)
+}
+
+func (d *Document) decode(kind string, spec map[string]interface{}) error {
+	res, ok := newResourceByKind(kind)
+	if !ok {
+		return fmt.Errorf("unknown kind: `%v`", kind)
+	}
+
+	known := make(map[string]bool)
+	for _, f := range getFields(res) {
+		known[f.name] = !f.readOnly()
+	}
+
+	attrs := make(map[string]string, len(spec))
+	for k, v := range spec {
+		if !known[k] || k == ".id" {
+			return fmt.Errorf("unknown attribute `%v` of %v", k, kind)
+		}
+
+		s, err := formatSpecValue(v)
+		if err != nil {
+			return fmt.Errorf("invalid value of `%v` of %v: %v", k, kind, err)
+		}
+		attrs[k] = s
+	}
+
+	if _, err := setFieldsFromMap(res, attrs); err != nil {
+		return fmt.Errorf("invalid %v: %v", kind, err)
+	}
+
+	if err := res.validate(); err != nil {
+		return fmt.Errorf("invalid %v: %v", kind, err)
+	}
+
+	d.Kind = kind
+	d.Spec = res
+
+	return nil
+}
+
+type specAttr struct {
+	name  string
+	value interface{}
+}
+
+func getSpecAttrs(res Resource) []specAttr {
+	var attrs []specAttr
+
+	for _, f := range getFields(res) {
+		if f.name == ".id" || f.readOnly() || f.value.IsZero() {
+			continue
+		}
+
+		fv := reflect.Indirect(f.value)
+
+		var v interface{}
+		switch fv.Kind() {
+		case reflect.Bool:
+			v = fv.Bool()
+		case reflect.Int:
+			v = fv.Int()
+		case reflect.Slice:
+			list := make([]string, fv.Len())
+			for i := range list {
+				list[i] = formatValue(fv.Index(i))
+			}
+			v = list
+		default:
+			v = formatValue(f.value)
+		}
+
+		attrs = append(attrs, specAttr{name: f.name, value: v})
+	}
+
+	return attrs
+}
+
+func formatSpecValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, e := range v {
+			s, err := formatSpecValue(e)
+			if err != nil {
+				return "", err
+			}
+			list[i] = s
+		}
+		return strings.Join(list, ","), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("unsupported value: %v", v)
+	}
+}
+
+// LoadResources loads resources from YAML or JSON documents. The input may
+// contain a stream of YAML documents, each of them being either a single
+// document or a list of documents. Since JSON is a subset of YAML, JSON
+// objects and arrays are accepted as well.
+func LoadResources(r io.Reader) ([]Resource, error) {
+	var res []Resource
+
+	dec := yaml.NewDecoder(r)
+
+	for {
+		var node yaml.Node
+
+		if err := dec.Decode(&node); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		var docs []Document
+
+		root := &node
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+
+		if root.Kind == 0 || root.Kind == yaml.DocumentNode {
+			// empty document
+			continue
+		}
+
+		if root.Kind == yaml.SequenceNode {
+			if err := root.Decode(&docs); err != nil {
+				return nil, err
+			}
+		} else {
+			var doc Document
+			if err := root.Decode(&doc); err != nil {
+				return nil, err
+			}
+			docs = append(docs, doc)
+		}
+
+		for _, doc := range docs {
+			res = append(res, doc.Spec)
+		}
+	}
+
+	return res, nil
+}
+
+// LoadResourcesFile loads resources from a YAML or JSON file.
+func LoadResourcesFile(path string) ([]Resource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadResources(f)
+}
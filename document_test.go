@@ -0,0 +1,138 @@
+package routerosclient
+
+import (
+	"encoding/json"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadResources(t *testing.T) {
+	inventory := `
+kind: InterfaceBridge
+spec:
+  name: br0
+  mtu: 1500
+---
+- kind: DHCPServer
+  spec:
+    interface: br0
+    name: dhcp1
+- kind: DHCPServerLease
+  spec:
+    address: 192.168.0.10
+    mac-address: 00:11:22:33:44:55
+    server: dhcp1
+    disabled: true
+---
+[{"kind": "DNSStaticRecord", "spec": {"address": "192.168.0.1", "name": "router.lan"}}]
+`
+
+	res, err := LoadResources(strings.NewReader(inventory))
+	if err != nil {
+		t.Fatalf("expected resources loaded, got error: %v", err)
+	}
+
+	expected := []Resource{
+		&ResourceInterfaceBridge{MTU: "1500", Name: "br0"},
+		&ResourceDHCPServer{Interface: "br0", Name: "dhcp1"},
+		&ResourceDHCPServerLease{
+			Address:    "192.168.0.10",
+			Disabled:   true,
+			MacAddress: "00:11:22:33:44:55",
+			Server:     "dhcp1",
+		},
+		&ResourceDNSStaticRecord{Address: "192.168.0.1", Name: "router.lan"},
+	}
+
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("expected %v, got %v", expected, res)
+	}
+}
+
+func TestLoadResourcesInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown kind":      "kind: Unknown\nspec:\n  name: x\n",
+		"unknown attribute": "kind: InterfaceBridge\nspec:\n  name: br0\n  colour: red\n",
+		"unknown field":     "kind: InterfaceBridge\nmeta: x\nspec:\n  name: br0\n",
+		"invalid type":      "kind: InterfaceBridge\nspec:\n  name: br0\n  mtu: big\n",
+		"validation":        "kind: DHCPServerLease\nspec:\n  address: 192.168.0.10\n  mac-address: x\n  server: dhcp1\n",
+		"missing required":  "kind: DNSStaticRecord\nspec:\n  address: 192.168.0.1\n",
+	}
+
+	for name, doc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadResources(strings.NewReader(doc)); err == nil {
+				t.Errorf("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestDocumentRoundTrip(t *testing.T) {
+	res := []Resource{
+		&ResourceDHCPServerOption{Code: 66, Name: "next-server", Value: "'192.168.0.2'"},
+		&ResourceDHCPServerLease{
+			ID:         "*1",
+			Address:    "192.168.0.10",
+			Comment:    "printer",
+			Disabled:   true,
+			MacAddress: "00:11:22:33:44:55",
+			Server:     "dhcp1",
+		},
+		&ResourceInterfaceBridge{Name: "br0", AgeingTime: 5 * time.Minute, ARP: ARPReplyOnly},
+		&ResourceInterfaceBridgeVLAN{Bridge: "br0", Tagged: []string{"br0", "ether1"}, VLANIDs: []string{"10", "20-29"}},
+		&ResourceIPAddress{Address: netip.MustParsePrefix("192.168.88.1/24"), Interface: "br0"},
+	}
+
+	expected := []Resource{
+		res[0],
+		&ResourceDHCPServerLease{
+			Address:    "192.168.0.10",
+			Comment:    "printer",
+			Disabled:   true,
+			MacAddress: "00:11:22:33:44:55",
+			Server:     "dhcp1",
+		},
+		res[2],
+		res[3],
+		res[4],
+	}
+
+	t.Run("yaml", func(t *testing.T) {
+		b, err := yaml.Marshal(NewDocuments(res))
+		if err != nil {
+			t.Fatalf("expected yaml, got error: %v", err)
+		}
+
+		loaded, err := LoadResources(strings.NewReader(string(b)))
+		if err != nil {
+			t.Fatalf("expected resources loaded, got error: %v\n%s", err, b)
+		}
+		if !reflect.DeepEqual(loaded, expected) {
+			t.Errorf("expected %v, got %v", expected, loaded)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		b, err := json.Marshal(NewDocuments(res))
+		if err != nil {
+			t.Fatalf("expected json, got error: %v", err)
+		}
+
+		var docs []Document
+		if err := json.Unmarshal(b, &docs); err != nil {
+			t.Fatalf("expected documents decoded, got error: %v\n%s", err, b)
+		}
+
+		for i, doc := range docs {
+			if !reflect.DeepEqual(doc.Spec, expected[i]) {
+				t.Errorf("expected %v, got %v", expected[i], doc.Spec)
+			}
+		}
+	})
+}
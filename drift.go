@@ -0,0 +1,161 @@
+package routerosclient
+
+import (
+	"log"
+)
+
+// DriftReport describes differences between expected resources and
+// the actual state of RouterOS.
+type DriftReport struct {
+	// Missing contains expected resources which don't exist on RouterOS.
+	Missing []DriftEntry `json:"missing,omitempty"`
+	// Extra contains resources which exist on RouterOS but are not expected.
+	// Dynamic resources (e.g. leases given out by DHCP server) are not reported.
+	Extra []DriftEntry `json:"extra,omitempty"`
+	// Changed contains resources which attributes differ from expected ones.
+	Changed []DriftEntry `json:"changed,omitempty"`
+}
+
+// DriftEntry describes a single drifted resource.
+type DriftEntry struct {
+	Menu   string            `json:"menu"`
+	ID     string            `json:"id,omitempty"`
+	Key    map[string]string `json:"key"`
+	Fields []FieldDrift      `json:"fields,omitempty"`
+}
+
+// FieldDrift describes a difference of a single attribute.
+type FieldDrift struct {
+	Attr     string `json:"attr"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// HasDrift reports whether any difference has been found.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Missing) > 0 || len(r.Extra) > 0 || len(r.Changed) > 0
+}
+
+// DetectDrift compares expected resources with resources of the same types
+// existing on RouterOS. Resources are matched by their keys. Only attributes
+// which are set in expected resources are compared: plain booleans, which are
+// always written, are always compared, while optional ones (`*bool`) are
+// compared only when not nil.
+func (c *Client) DetectDrift(expected []Resource) (*DriftReport, error) {
+	log.Printf("[D][~] DetectDrift(%v)", expected)
+
+	report := &DriftReport{}
+
+	var menus []string
+	byMenu := make(map[string][]Resource)
+
+	for _, res := range expected {
+		if err := res.validate(); err != nil {
+			return nil, err
+		}
+
+		menu := getResourceMenu(res)
+		if _, ok := byMenu[menu]; !ok {
+			menus = append(menus, menu)
+		}
+		byMenu[menu] = append(byMenu[menu], res)
+	}
+
+	for _, menu := range menus {
+		resources := byMenu[menu]
+
+		r, err := c.printResources(resources[0])
+		if err != nil {
+			return nil, err
+		}
+
+		var actual []Resource
+		for _, re := range r.Re {
+			if re.Map["dynamic"] == "true" {
+				continue
+			}
+
+			nr, err := newResource(resources[0])
+			if err != nil {
+				return nil, err
+			}
+
+			if _, err := setFieldsFromMap(nr, re.Map); err != nil {
+				return nil, err
+			}
+
+			actual = append(actual, nr)
+		}
+
+		matched := make([]bool, len(actual))
+
+		for _, res := range resources {
+			key := c.getResourceKey(res)
+			found := -1
+
+			for i, a := range actual {
+				if !matched[i] && matchesKey(a, key) {
+					found = i
+					break
+				}
+			}
+
+			if found < 0 {
+				report.Missing = append(report.Missing, DriftEntry{Menu: menu, Key: key})
+				continue
+			}
+			matched[found] = true
+
+			if diff := diffResources(res, actual[found]); len(diff) > 0 {
+				report.Changed = append(report.Changed, DriftEntry{
+					Menu:   menu,
+					ID:     actual[found].getID(),
+					Key:    key,
+					Fields: diff,
+				})
+			}
+		}
+
+		for i, a := range actual {
+			if !matched[i] {
+				report.Extra = append(report.Extra, DriftEntry{
+					Menu: menu,
+					ID:   a.getID(),
+					Key:  c.getResourceKey(a),
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func diffResources(expected, actual Resource) []FieldDrift {
+	var diff []FieldDrift
+
+	actualFields := getFields(actual)
+
+	for i, f := range getFields(expected) {
+		if f.name == ".id" || f.readOnly() {
+			continue
+		}
+
+		e := formatValue(f.value)
+		if e == "" {
+			continue
+		}
+
+		// defaults of renamed attributes don't apply to RouterOS 6, their
+		// values are always reported by RouterOS 7 anyway
+		a := formatValue(actualFields[i].value)
+		if _, renamed := f.options["v6"]; a == "" && !renamed {
+			a = f.options["default"]
+		}
+
+		if a != e {
+			diff = append(diff, FieldDrift{Attr: f.name, Expected: e, Actual: a})
+		}
+	}
+
+	return diff
+}
@@ -0,0 +1,103 @@
+package routerosclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-routeros/routeros"
+)
+
+func TestDetectDrift(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 2)}
+	c := &Client{conn: conn}
+
+	expected := []Resource{
+		&ResourceInterfaceBridge{Name: "br0", MTU: "1500"},
+		&ResourceInterfaceBridge{Name: "br1"},
+	}
+
+	conn.buildReply([]map[string]string{
+		{".id": "*1", "name": "br0", "mtu": "1400", "disabled": "false"},
+		{".id": "*2", "name": "br2", "mtu": "1500", "disabled": "false"},
+		{".id": "*3", "name": "br3", "dynamic": "true"},
+	}, nil)
+
+	report, err := c.DetectDrift(expected)
+	if err != nil {
+		t.Fatalf("expected report, got error: %v", err)
+	}
+
+	if !report.HasDrift() {
+		t.Fatalf("expected drift, got none")
+	}
+
+	expectedReport := &DriftReport{
+		Missing: []DriftEntry{
+			{Menu: "/interface/bridge", Key: map[string]string{"name": "br1"}},
+		},
+		Extra: []DriftEntry{
+			{Menu: "/interface/bridge", ID: "*2", Key: map[string]string{"name": "br2"}},
+		},
+		Changed: []DriftEntry{
+			{
+				Menu:   "/interface/bridge",
+				ID:     "*1",
+				Key:    map[string]string{"name": "br0"},
+				Fields: []FieldDrift{{Attr: "mtu", Expected: "1500", Actual: "1400"}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("expected %+v, got %+v", expectedReport, report)
+	}
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("expected report serialized, got error: %v", err)
+	}
+
+	var decoded DriftReport
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("expected report deserialized, got error: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, expectedReport) {
+		t.Errorf("expected %+v, got %+v", expectedReport, decoded)
+	}
+}
+
+func TestDetectDriftOptionalBool(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 2)}
+	c := &Client{conn: conn}
+
+	expected := []Resource{
+		&ResourceInterfaceBridge{Name: "br0"},
+		&ResourceInterfaceBridge{Name: "br1", FastForward: Bool(false)},
+	}
+
+	conn.buildReply([]map[string]string{
+		{".id": "*1", "name": "br0", "disabled": "false", "fast-forward": "true", "auto-mac": "true"},
+		{".id": "*2", "name": "br1", "disabled": "false", "fast-forward": "true", "auto-mac": "true"},
+	}, nil)
+
+	report, err := c.DetectDrift(expected)
+	if err != nil {
+		t.Fatalf("expected report, got error: %v", err)
+	}
+
+	expectedReport := &DriftReport{
+		Changed: []DriftEntry{
+			{
+				Menu:   "/interface/bridge",
+				ID:     "*2",
+				Key:    map[string]string{"name": "br1"},
+				Fields: []FieldDrift{{Attr: "fast-forward", Expected: "false", Actual: "true"}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("expected %+v, got %+v", expectedReport, report)
+	}
+}
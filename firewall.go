@@ -0,0 +1,92 @@
+package routerosclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// portProtocols are protocols allowing src-port and dst-port matchers.
+var portProtocols = map[string]bool{
+	"tcp": true, "udp": true, "udp-lite": true, "sctp": true, "dccp": true,
+	"6": true, "17": true, "132": true, "33": true, "136": true,
+}
+
+var connectionStates = map[string]bool{
+	"established": true, "related": true, "new": true, "invalid": true, "untracked": true,
+}
+
+// checkFirewallPorts checks ports are matched only along with a protocol having ports.
+func checkFirewallPorts(protocol, srcPort, dstPort string) error {
+	if srcPort == "" && dstPort == "" {
+		return nil
+	}
+
+	return checkPortProtocol(protocol)
+}
+
+// checkPortProtocol checks the protocol has ports, e.g. for to-ports of NAT rules.
+func checkPortProtocol(protocol string) error {
+	if !portProtocols[protocol] {
+		return fmt.Errorf("protocol: ports require tcp, udp, udp-lite, sctp or dccp, got %q", protocol)
+	}
+
+	return nil
+}
+
+// checkConnectionState checks connection states, which may be negated
+// as a whole by `!` prefix of the first one.
+func checkConnectionState(states []string) error {
+	for i, s := range states {
+		if i == 0 {
+			s = strings.TrimPrefix(s, "!")
+		}
+
+		if !connectionStates[s] {
+			return fmt.Errorf("connection-state: invalid value: %v", states[i])
+		}
+	}
+
+	return nil
+}
+
+// checkFirewallAction checks attributes required by actions common to
+// filter, raw and mangle rules.
+func checkFirewallAction(action, jumpTarget, addressList string) error {
+	switch action {
+	case "jump":
+		return requireField(action, "jump-target", jumpTarget)
+	case "add-dst-to-address-list", "add-src-to-address-list":
+		return requireField(action, "address-list", addressList)
+	}
+
+	return nil
+}
+
+// requireField returns error if the attribute required by the action is empty.
+func requireField(action, name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%v: required by action %v", name, action)
+	}
+
+	return nil
+}
+
+// checkPortRange checks a port or a range of ports, e.g. 8000-8080.
+func checkPortRange(s string) error {
+	var ports []int
+
+	for _, b := range strings.SplitN(s, "-", 2) {
+		port, err := strconv.Atoi(b)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port or range: %v", s)
+		}
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 2 && ports[1] < ports[0] {
+		return fmt.Errorf("invalid range: %v", s)
+	}
+
+	return nil
+}
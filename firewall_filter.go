@@ -0,0 +1,83 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceFirewallFilter is a firewall filter rule. Rules have no keys,
+// so the same rule may appear several times; use CreateResourceBefore,
+// MoveResource and ReconcileResources to manage their order.
+// The first of ConnectionState may be prefixed by `!` negating the match.
+type ResourceFirewallFilter struct {
+	ID               string   `ros:".id"`
+	Action           string   `ros:"action,default=accept"                        valid:"in(accept|add-dst-to-address-list|add-src-to-address-list|drop|fasttrack-connection|jump|log|passthrough|reject|return|tarpit),optional"`
+	AddressList      string   `ros:"address-list"                                 valid:"optional"`
+	Chain            string   `ros:"chain"                                        valid:"required"`
+	Comment          string   `ros:"comment"                                      valid:"optional"`
+	ConnectionState  []string `ros:"connection-state"                             valid:"optional"`
+	Disabled         bool     `ros:"disabled"                                     valid:"optional"`
+	Dynamic          bool     `ros:"dynamic,readonly"                             valid:"optional"`
+	DstAddress       string   `ros:"dst-address"                                  valid:"optional"`
+	DstAddressList   string   `ros:"dst-address-list"                             valid:"optional"`
+	DstPort          string   `ros:"dst-port"                                     valid:"optional"`
+	InInterface      string   `ros:"in-interface"                                 valid:"optional"`
+	InInterfaceList  string   `ros:"in-interface-list"                            valid:"optional"`
+	JumpTarget       string   `ros:"jump-target"                                  valid:"optional"`
+	Log              bool     `ros:"log"                                          valid:"optional"`
+	LogPrefix        string   `ros:"log-prefix"                                   valid:"optional"`
+	OutInterface     string   `ros:"out-interface"                                valid:"optional"`
+	OutInterfaceList string   `ros:"out-interface-list"                           valid:"optional"`
+	Protocol         string   `ros:"protocol"                                     valid:"optional"`
+	RejectWith       string   `ros:"reject-with,default=icmp-network-unreachable" valid:"optional"`
+	SrcAddress       string   `ros:"src-address"                                  valid:"optional"`
+	SrcAddressList   string   `ros:"src-address-list"                             valid:"optional"`
+	SrcPort          string   `ros:"src-port"                                     valid:"optional"`
+}
+
+func (d *ResourceFirewallFilter) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceFirewallFilter) getID() string {
+	return d.ID
+}
+
+func (d *ResourceFirewallFilter) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceFirewallFilter) getKeys() []string {
+	return nil
+}
+
+func (*ResourceFirewallFilter) getCreateCommand() string {
+	return "/ip/firewall/filter/add"
+}
+
+func (*ResourceFirewallFilter) getReadCommand() string {
+	return "/ip/firewall/filter/print"
+}
+
+func (*ResourceFirewallFilter) getUpdateCommand() string {
+	return "/ip/firewall/filter/set"
+}
+
+func (*ResourceFirewallFilter) getDeleteCommand() string {
+	return "/ip/firewall/filter/remove"
+}
+
+func (*ResourceFirewallFilter) getMoveCommand() string {
+	return "/ip/firewall/filter/move"
+}
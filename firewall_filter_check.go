@@ -0,0 +1,19 @@
+package routerosclient
+
+import "fmt"
+
+func (d *ResourceFirewallFilter) check() error {
+	if err := checkFirewallAction(d.Action, d.JumpTarget, d.AddressList); err != nil {
+		return err
+	}
+
+	if d.RejectWith != "" && d.Action != "reject" {
+		return fmt.Errorf("reject-with: requires action reject")
+	}
+
+	if err := checkFirewallPorts(d.Protocol, d.SrcPort, d.DstPort); err != nil {
+		return err
+	}
+
+	return checkConnectionState(d.ConnectionState)
+}
@@ -0,0 +1,89 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceFirewallMangle is a firewall mangle rule, e.g. marking
+// connections, packets or routing for policy routing. Mangle rules are
+// ordered like filter rules.
+// Nil Passthrough is unset, i.e. RouterOS default applies.
+type ResourceFirewallMangle struct {
+	ID                string   `ros:".id"`
+	Action            string   `ros:"action,default=accept"    valid:"in(accept|add-dst-to-address-list|add-src-to-address-list|change-dscp|change-mss|change-ttl|clear-df|fasttrack-connection|jump|log|mark-connection|mark-packet|mark-routing|passthrough|return|route|set-priority|strip-ipv4-options),optional"`
+	AddressList       string   `ros:"address-list"             valid:"optional"`
+	Chain             string   `ros:"chain"                    valid:"required"`
+	Comment           string   `ros:"comment"                  valid:"optional"`
+	ConnectionMark    string   `ros:"connection-mark"          valid:"optional"`
+	ConnectionState   []string `ros:"connection-state"         valid:"optional"`
+	Disabled          bool     `ros:"disabled"                 valid:"optional"`
+	Dynamic           bool     `ros:"dynamic,readonly"         valid:"optional"`
+	DstAddress        string   `ros:"dst-address"              valid:"optional"`
+	DstAddressList    string   `ros:"dst-address-list"         valid:"optional"`
+	DstPort           string   `ros:"dst-port"                 valid:"optional"`
+	InInterface       string   `ros:"in-interface"             valid:"optional"`
+	InInterfaceList   string   `ros:"in-interface-list"        valid:"optional"`
+	JumpTarget        string   `ros:"jump-target"              valid:"optional"`
+	Log               bool     `ros:"log"                      valid:"optional"`
+	LogPrefix         string   `ros:"log-prefix"               valid:"optional"`
+	NewConnectionMark string   `ros:"new-connection-mark"      valid:"optional"`
+	NewPacketMark     string   `ros:"new-packet-mark"          valid:"optional"`
+	NewRoutingMark    string   `ros:"new-routing-mark"         valid:"optional"`
+	OutInterface      string   `ros:"out-interface"            valid:"optional"`
+	OutInterfaceList  string   `ros:"out-interface-list"       valid:"optional"`
+	PacketMark        string   `ros:"packet-mark"              valid:"optional"`
+	Passthrough       *bool    `ros:"passthrough,default=true" valid:"optional"`
+	Protocol          string   `ros:"protocol"                 valid:"optional"`
+	RoutingMark       string   `ros:"routing-mark"             valid:"optional"`
+	SrcAddress        string   `ros:"src-address"              valid:"optional"`
+	SrcAddressList    string   `ros:"src-address-list"         valid:"optional"`
+	SrcPort           string   `ros:"src-port"                 valid:"optional"`
+}
+
+func (d *ResourceFirewallMangle) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceFirewallMangle) getID() string {
+	return d.ID
+}
+
+func (d *ResourceFirewallMangle) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceFirewallMangle) getKeys() []string {
+	return nil
+}
+
+func (*ResourceFirewallMangle) getCreateCommand() string {
+	return "/ip/firewall/mangle/add"
+}
+
+func (*ResourceFirewallMangle) getReadCommand() string {
+	return "/ip/firewall/mangle/print"
+}
+
+func (*ResourceFirewallMangle) getUpdateCommand() string {
+	return "/ip/firewall/mangle/set"
+}
+
+func (*ResourceFirewallMangle) getDeleteCommand() string {
+	return "/ip/firewall/mangle/remove"
+}
+
+func (*ResourceFirewallMangle) getMoveCommand() string {
+	return "/ip/firewall/mangle/move"
+}
@@ -0,0 +1,43 @@
+package routerosclient
+
+import "fmt"
+
+// mangleMarks maps marking actions to attributes holding the new mark.
+var mangleMarks = map[string]string{
+	"mark-connection": "new-connection-mark",
+	"mark-packet":     "new-packet-mark",
+	"mark-routing":    "new-routing-mark",
+}
+
+func (d *ResourceFirewallMangle) check() error {
+	if err := checkFirewallAction(d.Action, d.JumpTarget, d.AddressList); err != nil {
+		return err
+	}
+
+	marks := map[string]string{
+		"new-connection-mark": d.NewConnectionMark,
+		"new-packet-mark":     d.NewPacketMark,
+		"new-routing-mark":    d.NewRoutingMark,
+	}
+
+	for _, name := range []string{"new-connection-mark", "new-packet-mark", "new-routing-mark"} {
+		if mangleMarks[d.Action] == name {
+			if err := requireField(d.Action, name, marks[name]); err != nil {
+				return err
+			}
+		} else if marks[name] != "" {
+			return fmt.Errorf("%v: not used by action %v", name, d.Action)
+		}
+	}
+
+	// routing decision is made right after these chains
+	if d.Action == "mark-routing" && (d.Chain == "input" || d.Chain == "forward" || d.Chain == "postrouting") {
+		return fmt.Errorf("action %v: not allowed in chain %v", d.Action, d.Chain)
+	}
+
+	if err := checkFirewallPorts(d.Protocol, d.SrcPort, d.DstPort); err != nil {
+		return err
+	}
+
+	return checkConnectionState(d.ConnectionState)
+}
@@ -0,0 +1,83 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceFirewallNAT is a firewall NAT rule. Chain is either `srcnat`,
+// `dstnat` or a custom chain jumped to. Like filter rules, NAT rules have
+// no keys and are ordered by CreateResourceBefore, MoveResource and
+// ReconcileResources.
+// ToAddresses is an address or a range, ToPorts is a port or a range.
+type ResourceFirewallNAT struct {
+	ID               string `ros:".id"`
+	Action           string `ros:"action,default=accept" valid:"in(accept|dst-nat|jump|log|masquerade|netmap|passthrough|redirect|return|same|src-nat),optional"`
+	Chain            string `ros:"chain"                 valid:"required"`
+	Comment          string `ros:"comment"               valid:"optional"`
+	Disabled         bool   `ros:"disabled"              valid:"optional"`
+	Dynamic          bool   `ros:"dynamic,readonly"      valid:"optional"`
+	DstAddress       string `ros:"dst-address"           valid:"optional"`
+	DstAddressList   string `ros:"dst-address-list"      valid:"optional"`
+	DstPort          string `ros:"dst-port"              valid:"optional"`
+	InInterface      string `ros:"in-interface"          valid:"optional"`
+	InInterfaceList  string `ros:"in-interface-list"     valid:"optional"`
+	JumpTarget       string `ros:"jump-target"           valid:"optional"`
+	Log              bool   `ros:"log"                   valid:"optional"`
+	LogPrefix        string `ros:"log-prefix"            valid:"optional"`
+	OutInterface     string `ros:"out-interface"         valid:"optional"`
+	OutInterfaceList string `ros:"out-interface-list"    valid:"optional"`
+	Protocol         string `ros:"protocol"              valid:"optional"`
+	SrcAddress       string `ros:"src-address"           valid:"optional"`
+	SrcAddressList   string `ros:"src-address-list"      valid:"optional"`
+	SrcPort          string `ros:"src-port"              valid:"optional"`
+	ToAddresses      string `ros:"to-addresses"          valid:"optional"`
+	ToPorts          string `ros:"to-ports"              valid:"optional"`
+}
+
+func (d *ResourceFirewallNAT) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceFirewallNAT) getID() string {
+	return d.ID
+}
+
+func (d *ResourceFirewallNAT) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceFirewallNAT) getKeys() []string {
+	return nil
+}
+
+func (*ResourceFirewallNAT) getCreateCommand() string {
+	return "/ip/firewall/nat/add"
+}
+
+func (*ResourceFirewallNAT) getReadCommand() string {
+	return "/ip/firewall/nat/print"
+}
+
+func (*ResourceFirewallNAT) getUpdateCommand() string {
+	return "/ip/firewall/nat/set"
+}
+
+func (*ResourceFirewallNAT) getDeleteCommand() string {
+	return "/ip/firewall/nat/remove"
+}
+
+func (*ResourceFirewallNAT) getMoveCommand() string {
+	return "/ip/firewall/nat/move"
+}
@@ -0,0 +1,67 @@
+package routerosclient
+
+import "fmt"
+
+// natChains maps actions bound to a built-in chain to the chain.
+var natChains = map[string]string{
+	"masquerade": "srcnat",
+	"src-nat":    "srcnat",
+	"same":       "srcnat",
+	"dst-nat":    "dstnat",
+	"redirect":   "dstnat",
+}
+
+func (d *ResourceFirewallNAT) check() error {
+	switch d.Action {
+	case "jump":
+		if err := requireField(d.Action, "jump-target", d.JumpTarget); err != nil {
+			return err
+		}
+	case "dst-nat", "src-nat":
+		if d.ToAddresses == "" && d.ToPorts == "" {
+			return fmt.Errorf("to-addresses or to-ports: required by action %v", d.Action)
+		}
+	case "netmap", "same":
+		if err := requireField(d.Action, "to-addresses", d.ToAddresses); err != nil {
+			return err
+		}
+	case "redirect":
+		if err := requireField(d.Action, "to-ports", d.ToPorts); err != nil {
+			return err
+		}
+	}
+
+	if chain, ok := natChains[d.Action]; ok && (d.Chain == "srcnat" || d.Chain == "dstnat") && d.Chain != chain {
+		return fmt.Errorf("action %v: not allowed in chain %v", d.Action, d.Chain)
+	}
+
+	if d.ToAddresses != "" {
+		switch d.Action {
+		case "dst-nat", "src-nat", "netmap", "same":
+		default:
+			return fmt.Errorf("to-addresses: not used by action %v", d.Action)
+		}
+
+		if err := checkIPRange(d.ToAddresses); err != nil {
+			return fmt.Errorf("to-addresses: %v", err)
+		}
+	}
+
+	if d.ToPorts != "" {
+		switch d.Action {
+		case "dst-nat", "src-nat", "netmap", "same", "masquerade", "redirect":
+		default:
+			return fmt.Errorf("to-ports: not used by action %v", d.Action)
+		}
+
+		if err := checkPortRange(d.ToPorts); err != nil {
+			return fmt.Errorf("to-ports: %v", err)
+		}
+
+		if err := checkPortProtocol(d.Protocol); err != nil {
+			return err
+		}
+	}
+
+	return checkFirewallPorts(d.Protocol, d.SrcPort, d.DstPort)
+}
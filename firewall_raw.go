@@ -0,0 +1,81 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceFirewallRaw is a firewall raw rule, processed before connection
+// tracking, e.g. to drop attack traffic early. Chain is either
+// `prerouting`, `output` or a custom chain jumped to. Raw rules are
+// ordered like filter rules.
+type ResourceFirewallRaw struct {
+	ID               string `ros:".id"`
+	Action           string `ros:"action,default=accept" valid:"in(accept|add-dst-to-address-list|add-src-to-address-list|drop|jump|log|notrack|passthrough|return),optional"`
+	AddressList      string `ros:"address-list"          valid:"optional"`
+	Chain            string `ros:"chain"                 valid:"required"`
+	Comment          string `ros:"comment"               valid:"optional"`
+	Disabled         bool   `ros:"disabled"              valid:"optional"`
+	Dynamic          bool   `ros:"dynamic,readonly"      valid:"optional"`
+	DstAddress       string `ros:"dst-address"           valid:"optional"`
+	DstAddressList   string `ros:"dst-address-list"      valid:"optional"`
+	DstPort          string `ros:"dst-port"              valid:"optional"`
+	InInterface      string `ros:"in-interface"          valid:"optional"`
+	InInterfaceList  string `ros:"in-interface-list"     valid:"optional"`
+	JumpTarget       string `ros:"jump-target"           valid:"optional"`
+	Log              bool   `ros:"log"                   valid:"optional"`
+	LogPrefix        string `ros:"log-prefix"            valid:"optional"`
+	OutInterface     string `ros:"out-interface"         valid:"optional"`
+	OutInterfaceList string `ros:"out-interface-list"    valid:"optional"`
+	Protocol         string `ros:"protocol"              valid:"optional"`
+	SrcAddress       string `ros:"src-address"           valid:"optional"`
+	SrcAddressList   string `ros:"src-address-list"      valid:"optional"`
+	SrcPort          string `ros:"src-port"              valid:"optional"`
+}
+
+func (d *ResourceFirewallRaw) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceFirewallRaw) getID() string {
+	return d.ID
+}
+
+func (d *ResourceFirewallRaw) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceFirewallRaw) getKeys() []string {
+	return nil
+}
+
+func (*ResourceFirewallRaw) getCreateCommand() string {
+	return "/ip/firewall/raw/add"
+}
+
+func (*ResourceFirewallRaw) getReadCommand() string {
+	return "/ip/firewall/raw/print"
+}
+
+func (*ResourceFirewallRaw) getUpdateCommand() string {
+	return "/ip/firewall/raw/set"
+}
+
+func (*ResourceFirewallRaw) getDeleteCommand() string {
+	return "/ip/firewall/raw/remove"
+}
+
+func (*ResourceFirewallRaw) getMoveCommand() string {
+	return "/ip/firewall/raw/move"
+}
@@ -0,0 +1,21 @@
+package routerosclient
+
+import "fmt"
+
+func (d *ResourceFirewallRaw) check() error {
+	if err := checkFirewallAction(d.Action, d.JumpTarget, d.AddressList); err != nil {
+		return err
+	}
+
+	// raw rules see packets before routing decision
+	switch d.Chain {
+	case "input", "forward", "postrouting":
+		return fmt.Errorf("chain %v: not available in raw table", d.Chain)
+	case "prerouting":
+		if d.OutInterface != "" || d.OutInterfaceList != "" {
+			return fmt.Errorf("out-interface: not known in chain %v", d.Chain)
+		}
+	}
+
+	return checkFirewallPorts(d.Protocol, d.SrcPort, d.DstPort)
+}
@@ -0,0 +1,151 @@
+package routerosclient
+
+import "testing"
+
+func TestValidateFirewallFilter(t *testing.T) {
+	invalid := []*ResourceFirewallFilter{
+		{Action: "accept"},
+		{Chain: "input", Action: "permit"},
+		{Chain: "input", Action: "jump"},
+		{Chain: "input", Action: "add-src-to-address-list"},
+		{Chain: "input", Action: "drop", RejectWith: "tcp-reset"},
+		{Chain: "input", Action: "accept", DstPort: "22"},
+		{Chain: "input", Action: "accept", Protocol: "icmp", DstPort: "22"},
+		{Chain: "input", Action: "accept", Protocol: "!tcp", SrcPort: "22"},
+		{Chain: "input", Action: "accept", ConnectionState: []string{"established", "!related"}},
+		{Chain: "input", Action: "accept", ConnectionState: []string{"open"}},
+	}
+
+	for _, r := range invalid {
+		if err := r.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", r)
+		}
+	}
+
+	valid := []*ResourceFirewallFilter{
+		{Chain: "input"},
+		{Chain: "forward", Action: "jump", JumpTarget: "lan"},
+		{Chain: "input", Action: "add-src-to-address-list", AddressList: "scanners"},
+		{Chain: "input", Action: "reject", RejectWith: "tcp-reset", Protocol: "tcp"},
+		{Chain: "input", Action: "accept", Protocol: "udp", DstPort: "53,123"},
+		{Chain: "input", Action: "drop", ConnectionState: []string{"!established", "related"}},
+	}
+
+	for _, r := range valid {
+		if err := r.validate(); err != nil {
+			t.Errorf("expected valid rule %+v, got error: %v", r, err)
+		}
+	}
+}
+
+func TestValidateFirewallNAT(t *testing.T) {
+	invalid := []*ResourceFirewallNAT{
+		{Action: "masquerade"},
+		{Chain: "srcnat", Action: "snat"},
+		{Chain: "dstnat", Action: "dst-nat"},
+		{Chain: "dstnat", Action: "dst-nat", ToAddresses: "192.168.88.10-192.168.88.1"},
+		{Chain: "dstnat", Action: "dst-nat", ToAddresses: "2001:db8::1"},
+		{Chain: "srcnat", Action: "src-nat"},
+		{Chain: "srcnat", Action: "netmap"},
+		{Chain: "srcnat", Action: "same", ToPorts: "80", Protocol: "tcp"},
+		{Chain: "dstnat", Action: "redirect"},
+		{Chain: "dstnat", Action: "jump"},
+		{Chain: "dstnat", Action: "masquerade"},
+		{Chain: "srcnat", Action: "dst-nat", ToAddresses: "192.168.88.10"},
+		{Chain: "srcnat", Action: "masquerade", ToAddresses: "192.168.88.10"},
+		{Chain: "dstnat", Action: "dst-nat", ToAddresses: "192.168.88.10", ToPorts: "80"},
+		{Chain: "dstnat", Action: "dst-nat", ToAddresses: "192.168.88.10", Protocol: "tcp", ToPorts: "70000"},
+		{Chain: "dstnat", Action: "accept", Protocol: "tcp", ToPorts: "80"},
+	}
+
+	for _, r := range invalid {
+		if err := r.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", r)
+		}
+	}
+
+	valid := []*ResourceFirewallNAT{
+		{Chain: "srcnat", Action: "masquerade", OutInterface: "ether1"},
+		{Chain: "dstnat", Action: "dst-nat", Protocol: "tcp", DstPort: "8080", ToAddresses: "192.168.88.10", ToPorts: "80"},
+		{Chain: "srcnat", Action: "src-nat", ToAddresses: "203.0.113.1-203.0.113.9"},
+		{Chain: "dstnat", Action: "netmap", ToAddresses: "192.168.88.0/24"},
+		{Chain: "dstnat", Action: "redirect", Protocol: "udp", DstPort: "53", ToPorts: "53"},
+		{Chain: "dstnat", Action: "jump", JumpTarget: "port-forwards"},
+		{Chain: "dstnat", Action: "dst-nat", Protocol: "tcp", DstPort: "80", ToPorts: "8080"},
+		{Chain: "srcnat", Action: "src-nat", Protocol: "udp", ToPorts: "10000-20000"},
+		{Chain: "port-forwards", Action: "dst-nat", ToAddresses: "192.168.88.10"},
+	}
+
+	for _, r := range valid {
+		if err := r.validate(); err != nil {
+			t.Errorf("expected valid rule %+v, got error: %v", r, err)
+		}
+	}
+}
+
+func TestValidateFirewallMangle(t *testing.T) {
+	invalid := []*ResourceFirewallMangle{
+		{Action: "mark-routing", NewRoutingMark: "vpn"},
+		{Chain: "prerouting", Action: "mark-routing"},
+		{Chain: "prerouting", Action: "mark-connection"},
+		{Chain: "prerouting", Action: "mark-packet"},
+		{Chain: "prerouting", Action: "mark-connection", NewConnectionMark: "vpn", NewRoutingMark: "vpn"},
+		{Chain: "prerouting", Action: "accept", NewPacketMark: "p"},
+		{Chain: "forward", Action: "mark-routing", NewRoutingMark: "vpn"},
+		{Chain: "prerouting", Action: "jump"},
+		{Chain: "prerouting", Action: "mark-connection", NewConnectionMark: "web", DstPort: "443"},
+		{Chain: "prerouting", Action: "mark-connection", NewConnectionMark: "web", ConnectionState: []string{"open"}},
+	}
+
+	for _, r := range invalid {
+		if err := r.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", r)
+		}
+	}
+
+	valid := []*ResourceFirewallMangle{
+		{Chain: "prerouting", Action: "mark-connection", NewConnectionMark: "vpn", Passthrough: Bool(true),
+			InInterface: "bridge", DstAddressList: "via-vpn", ConnectionState: []string{"new"}},
+		{Chain: "prerouting", Action: "mark-routing", NewRoutingMark: "vpn", ConnectionMark: "vpn"},
+		{Chain: "output", Action: "mark-routing", NewRoutingMark: "vpn"},
+		{Chain: "forward", Action: "mark-packet", NewPacketMark: "web", Protocol: "tcp", DstPort: "443", Passthrough: Bool(true)},
+		{Chain: "postrouting", Action: "passthrough"},
+	}
+
+	for _, r := range valid {
+		if err := r.validate(); err != nil {
+			t.Errorf("expected valid rule %+v, got error: %v", r, err)
+		}
+	}
+}
+
+func TestValidateFirewallRaw(t *testing.T) {
+	invalid := []*ResourceFirewallRaw{
+		{Action: "drop"},
+		{Chain: "prerouting", Action: "reject"},
+		{Chain: "input", Action: "drop"},
+		{Chain: "prerouting", Action: "drop", OutInterface: "ether1"},
+		{Chain: "prerouting", Action: "add-src-to-address-list"},
+		{Chain: "prerouting", Action: "drop", Protocol: "icmp", DstPort: "53"},
+	}
+
+	for _, r := range invalid {
+		if err := r.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", r)
+		}
+	}
+
+	valid := []*ResourceFirewallRaw{
+		{Chain: "prerouting", Action: "drop", SrcAddressList: "blacklist", InInterface: "ether1"},
+		{Chain: "prerouting", Action: "notrack", Protocol: "udp", DstPort: "53"},
+		{Chain: "output", Action: "accept", OutInterface: "ether1"},
+		{Chain: "prerouting", Action: "jump", JumpTarget: "ddos"},
+		{Chain: "ddos", Action: "add-src-to-address-list", AddressList: "blacklist"},
+	}
+
+	for _, r := range valid {
+		if err := r.validate(); err != nil {
+			t.Errorf("expected valid rule %+v, got error: %v", r, err)
+		}
+	}
+}
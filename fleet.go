@@ -0,0 +1,382 @@
+package routerosclient
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Inventory describes a fleet of routers:
+//
+//	netrc: /etc/routeros/netrc
+//	routers:
+//	  - name: core1
+//	    address: 10.0.0.1
+//	    labels: {site: ams, role: core}
+//	  - name: edge1
+//	    address: edge1.example.net
+//	    labels: {site: ams, role: edge}
+//	    username: admin
+//	    password-file: /run/secrets/edge1
+//	    tls: true
+//
+// Routers without own credentials use the netrc file.
+type Inventory struct {
+	Netrc   string            `yaml:"netrc"`
+	Routers []InventoryRouter `yaml:"routers"`
+}
+
+// InventoryRouter is a router of an inventory. Password is taken from
+// Password, PasswordEnv or PasswordFile, whichever is set, and requires
+// Username.
+type InventoryRouter struct {
+	Name         string            `yaml:"name"`
+	Address      string            `yaml:"address"`
+	Labels       map[string]string `yaml:"labels"`
+	Username     string            `yaml:"username"`
+	Password     string            `yaml:"password"`
+	PasswordEnv  string            `yaml:"password-env"`
+	PasswordFile string            `yaml:"password-file"`
+	TLS          bool              `yaml:"tls"`
+	REST         bool              `yaml:"rest"`
+}
+
+// LoadInventory loads an inventory from YAML.
+func LoadInventory(r io.Reader) (*Inventory, error) {
+	inv := &Inventory{}
+
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(inv); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, r := range inv.Routers {
+		if r.Name == "" {
+			return nil, fmt.Errorf("router name is required")
+		}
+
+		if names[r.Name] {
+			return nil, fmt.Errorf("duplicate router: %v", r.Name)
+		}
+		names[r.Name] = true
+
+		if _, err := inv.config(r); err != nil {
+			return nil, fmt.Errorf("router %v: %v", r.Name, err)
+		}
+	}
+
+	return inv, nil
+}
+
+// LoadInventoryFile loads an inventory from a YAML file.
+func LoadInventoryFile(path string) (*Inventory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadInventory(f)
+}
+
+// config returns client config of the router.
+func (inv *Inventory) config(r InventoryRouter) (*Config, error) {
+	conf := &Config{
+		Address: r.Address,
+		REST:    r.REST,
+	}
+
+	if r.TLS {
+		conf.TLSConfig = &TLSConfig{}
+	}
+
+	if r.Username == "" && (r.Password != "" || r.PasswordEnv != "" || r.PasswordFile != "") {
+		return nil, fmt.Errorf("username: required along with password")
+	}
+
+	switch {
+	case r.Password != "":
+		conf.Credentials = StaticCredentials{Username: r.Username, Password: r.Password}
+	case r.PasswordEnv != "":
+		conf.Credentials = EnvCredentials{Username: r.Username, PasswordVar: r.PasswordEnv}
+	case r.PasswordFile != "":
+		conf.Credentials = FileCredentials{Username: r.Username, PasswordFile: r.PasswordFile}
+	case inv.Netrc != "":
+		conf.Credentials = NetrcCredentials{Path: inv.Netrc}
+	default:
+		return nil, fmt.Errorf("no credentials")
+	}
+
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// Connect connects to all routers of the inventory, at most parallelism
+// of them at once. Routers which failed to connect are reported by
+// *FleetError and left out of the returned fleet.
+func (inv *Inventory) Connect(parallelism int) (*Fleet, error) {
+	f := NewFleet(parallelism)
+
+	for _, r := range inv.Routers {
+		f.routers = append(f.routers, &fleetRouter{name: r.Name, labels: r.Labels})
+	}
+	f.sort()
+
+	byName := make(map[string]InventoryRouter)
+	for _, r := range inv.Routers {
+		byName[r.Name] = r
+	}
+
+	results := f.do(func(fr *fleetRouter, r *FleetResult) error {
+		conf, err := inv.config(byName[fr.name])
+		if err != nil {
+			return err
+		}
+
+		c, err := NewClient(conf)
+		if err != nil {
+			return err
+		}
+		fr.client = c
+
+		return nil
+	})
+
+	connected := f.routers[:0]
+	for _, fr := range f.routers {
+		if fr.client != nil {
+			connected = append(connected, fr)
+		}
+	}
+	f.routers = connected
+
+	return f, results.Err()
+}
+
+// Fleet is a set of named clients, which runs operations on all of them
+// concurrently.
+type Fleet struct {
+	parallelism int
+	routers     []*fleetRouter // sorted by name
+}
+
+type fleetRouter struct {
+	name   string
+	labels map[string]string
+	client *Client
+}
+
+// FleetResult is a result of an operation run on a router.
+type FleetResult struct {
+	Router    string
+	ID        string     // id of created or ensured resource
+	Changed   bool       // whether ensured resource has been changed
+	Resources []Resource // listed resources
+	Err       error
+}
+
+// FleetResults are results of an operation run on a fleet, sorted by router name.
+type FleetResults []FleetResult
+
+// Err returns *FleetError, if the operation failed on any router.
+func (rs FleetResults) Err() error {
+	errs := make(map[string]error)
+
+	for _, r := range rs {
+		if r.Err != nil {
+			errs[r.Router] = r.Err
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &FleetError{Errors: errs, Total: len(rs)}
+}
+
+// FleetError describes routers an operation failed on.
+type FleetError struct {
+	Errors map[string]error // by router name
+	Total  int              // number of routers the operation was run on
+}
+
+func (e *FleetError) Error() string {
+	names := make([]string, 0, len(e.Errors))
+	for name := range e.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = fmt.Sprintf("%v: %v", name, e.Errors[name])
+	}
+
+	return fmt.Sprintf("failed on %v of %v routers: %v", len(names), e.Total, strings.Join(msgs, "; "))
+}
+
+// NewFleet returns an empty fleet running operations on at most
+// parallelism routers at once. Zero or negative parallelism means no limit.
+func NewFleet(parallelism int) *Fleet {
+	return &Fleet{parallelism: parallelism}
+}
+
+// Add adds a client to the fleet.
+func (f *Fleet) Add(name string, c *Client, labels map[string]string) error {
+	if _, ok := f.Client(name); ok {
+		return fmt.Errorf("duplicate router: %v", name)
+	}
+
+	f.routers = append(f.routers, &fleetRouter{name: name, labels: labels, client: c})
+	f.sort()
+
+	return nil
+}
+
+func (f *Fleet) sort() {
+	sort.Slice(f.routers, func(i, j int) bool { return f.routers[i].name < f.routers[j].name })
+}
+
+// Names returns sorted names of routers in the fleet.
+func (f *Fleet) Names() []string {
+	names := make([]string, len(f.routers))
+	for i, fr := range f.routers {
+		names[i] = fr.name
+	}
+
+	return names
+}
+
+// Client returns client of the named router.
+func (f *Fleet) Client(name string) (*Client, bool) {
+	for _, fr := range f.routers {
+		if fr.name == name {
+			return fr.client, true
+		}
+	}
+
+	return nil, false
+}
+
+// Select returns a fleet of routers having all the given labels. Clients are
+// shared with the original fleet.
+func (f *Fleet) Select(labels map[string]string) *Fleet {
+	s := NewFleet(f.parallelism)
+
+	for _, fr := range f.routers {
+		matched := true
+		for k, v := range labels {
+			if fr.labels[k] != v {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			s.routers = append(s.routers, fr)
+		}
+	}
+
+	return s
+}
+
+// Close closes all clients of the fleet.
+func (f *Fleet) Close() {
+	for _, fr := range f.routers {
+		fr.client.Close()
+	}
+}
+
+// Do runs fn on every router concurrently. The result is filled in by fn,
+// the returned error is recorded in it.
+func (f *Fleet) Do(fn func(c *Client, r *FleetResult) error) FleetResults {
+	return f.do(func(fr *fleetRouter, r *FleetResult) error {
+		return fn(fr.client, r)
+	})
+}
+
+func (f *Fleet) do(fn func(fr *fleetRouter, r *FleetResult) error) FleetResults {
+	results := make(FleetResults, len(f.routers))
+
+	limit := f.parallelism
+	if limit <= 0 || limit > len(f.routers) {
+		limit = len(f.routers)
+	}
+	sem := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+
+	for i, fr := range f.routers {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(fr *fleetRouter, r *FleetResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			r.Router = fr.name
+			r.Err = fn(fr, r)
+		}(fr, &results[i])
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// CreateResource creates the resource on every router.
+func (f *Fleet) CreateResource(res Resource) FleetResults {
+	return f.Do(func(c *Client, r *FleetResult) error {
+		id, err := c.CreateResource(cloneResource(res))
+		r.ID = id
+		return err
+	})
+}
+
+// UpdateResource updates the resource on every router.
+func (f *Fleet) UpdateResource(o Resource, n Resource) FleetResults {
+	return f.Do(func(c *Client, r *FleetResult) error {
+		err, _ := c.UpdateResource(cloneResource(o), cloneResource(n))
+		return err
+	})
+}
+
+// DeleteResource deletes the resource on every router.
+func (f *Fleet) DeleteResource(res Resource) FleetResults {
+	return f.Do(func(c *Client, r *FleetResult) error {
+		err, _ := c.DeleteResource(cloneResource(res))
+		return err
+	})
+}
+
+// ListResources lists resources of the same type as res on every router.
+func (f *Fleet) ListResources(res Resource) FleetResults {
+	return f.Do(func(c *Client, r *FleetResult) error {
+		list, err := c.ListResources(res)
+		r.Resources = list
+		return err
+	})
+}
+
+// EnsureResource ensures the resource on every router.
+func (f *Fleet) EnsureResource(res Resource) FleetResults {
+	return f.Do(func(c *Client, r *FleetResult) error {
+		id, changed, err := c.EnsureResource(cloneResource(res))
+		r.ID, r.Changed = id, changed
+		return err
+	})
+}
@@ -0,0 +1,188 @@
+package routerosclient
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newFakeDNSRouter(t *testing.T, records []map[string]string) *FakeRouterOS {
+	return newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		switch words[0] {
+		case "/ip/dns/static/print":
+			for _, rec := range records {
+				r.Re(rec)
+			}
+			r.Done(nil)
+		case "/ip/dns/static/add":
+			r.Done(map[string]string{"ret": "*2"})
+		default:
+			r.Done(nil)
+		}
+	})
+}
+
+func TestFleet(t *testing.T) {
+	f1 := newFakeDNSRouter(t, []map[string]string{
+		{".id": "*1", "name": "router.lan", "address": "10.0.0.1", "disabled": "false"},
+	})
+	f2 := newFakeDNSRouter(t, nil)
+	f3 := newFakeDNSRouter(t, nil)
+
+	inv, err := LoadInventory(strings.NewReader(fmt.Sprintf(`
+routers:
+  - name: r1
+    address: %v
+    labels: {site: ams}
+    username: admin
+    password: admin
+  - name: r2
+    address: %v
+    labels: {site: ams}
+    username: admin
+    password: admin
+  - name: r3
+    address: %v
+    labels: {site: fra}
+    username: admin
+    password: admin
+  - name: r4
+    address: 127.0.0.1:1
+    username: admin
+    password: admin
+`, f1.Addr(), f2.Addr(), f3.Addr())))
+	if err != nil {
+		t.Fatalf("expected inventory loaded, got error: %v", err)
+	}
+
+	fleet, err := inv.Connect(2)
+	if ferr, ok := err.(*FleetError); !ok || len(ferr.Errors) != 1 || ferr.Errors["r4"] == nil {
+		t.Errorf("expected r4 failed to connect, got %v", err)
+	}
+	defer fleet.Close()
+
+	if names := strings.Join(fleet.Names(), ","); names != "r1,r2,r3" {
+		t.Errorf("expected r1,r2,r3 connected, got %v", names)
+	}
+
+	ams := fleet.Select(map[string]string{"site": "ams"})
+	if names := strings.Join(ams.Names(), ","); names != "r1,r2" {
+		t.Errorf("expected r1,r2 selected, got %v", names)
+	}
+
+	results := ams.EnsureResource(&ResourceDNSStaticRecord{Address: "10.0.0.1", Name: "router.lan"})
+	if err := results.Err(); err != nil {
+		t.Fatalf("expected resource ensured, got error: %v", err)
+	}
+
+	if r := results[0]; r.Router != "r1" || r.ID != "*1" || r.Changed {
+		t.Errorf("expected r1 unchanged, got %+v", r)
+	}
+
+	if r := results[1]; r.Router != "r2" || r.ID != "*2" || !r.Changed {
+		t.Errorf("expected r2 created, got %+v", r)
+	}
+
+	if cmds := f3.Commands(); len(cmds) != 0 {
+		t.Errorf("expected r3 untouched, got %v", cmds)
+	}
+
+	results = fleet.ListResources(&ResourceDNSStaticRecord{})
+	if err := results.Err(); err != nil || len(results) != 3 || len(results[0].Resources) != 1 {
+		t.Errorf("expected resources listed, got %+v, %v", results, err)
+	}
+}
+
+func TestLoadInventoryErrors(t *testing.T) {
+	tests := map[string]string{
+		"without name": `
+routers:
+  - address: 10.0.0.1
+    username: admin
+    password: admin
+`,
+		"duplicate router": `
+routers:
+  - name: r1
+    address: 10.0.0.1
+    username: admin
+    password: admin
+  - name: r1
+    address: 10.0.0.2
+    username: admin
+    password: admin
+`,
+		"without credentials": `
+routers:
+  - name: r1
+    address: 10.0.0.1
+`,
+		"password without username": `
+routers:
+  - name: r1
+    address: 10.0.0.1
+    password: admin
+`,
+		"password file without username": `
+netrc: /etc/routeros/netrc
+routers:
+  - name: r1
+    address: 10.0.0.1
+    password-file: /run/secrets/r1
+`,
+	}
+
+	for name, inv := range tests {
+		if _, err := LoadInventory(strings.NewReader(inv)); err == nil {
+			t.Errorf("%v: expected error, got nil", name)
+		}
+	}
+}
+
+func TestFleetParallelism(t *testing.T) {
+	fleet := NewFleet(2)
+
+	for i := 0; i < 5; i++ {
+		if err := fleet.Add(fmt.Sprintf("r%v", i), &Client{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := fleet.Add("r0", &Client{}, nil); err == nil {
+		t.Errorf("expected error adding duplicate router, got nil")
+	}
+
+	var mu sync.Mutex
+	running, max := 0, 0
+
+	results := fleet.Do(func(c *Client, r *FleetResult) error {
+		mu.Lock()
+		running++
+		if running > max {
+			max = running
+		}
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		if r.Router == "r3" {
+			return fmt.Errorf("failed")
+		}
+
+		return nil
+	})
+
+	if max > 2 {
+		t.Errorf("expected at most 2 routers at once, got %v", max)
+	}
+
+	err := results.Err()
+	if err == nil || err.Error() != "failed on 1 of 5 routers: r3: failed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
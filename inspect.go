@@ -0,0 +1,223 @@
+package routerosclient
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// arguments which are not attributes of resources
+var inspectIgnoredArgs = map[string]bool{
+	".id":          true,
+	"numbers":      true,
+	"copy-from":    true,
+	"place-before": true,
+}
+
+// MenuSchema describes a menu as reported by /console/inspect.
+type MenuSchema struct {
+	Menu     string
+	Menus    []string            // names of child menus
+	Commands map[string][]string // names of arguments by command
+}
+
+// SchemaDiff describes differences between attributes of a resource type and
+// attributes supported by the connected RouterOS.
+type SchemaDiff struct {
+	Menu string `json:"menu"`
+	// Missing contains attributes supported by RouterOS but not modeled by the resource.
+	Missing []string `json:"missing,omitempty"`
+	// Unknown contains attributes modeled by the resource but not supported by
+	// RouterOS, which usually means they're misnamed or have been renamed.
+	Unknown []string `json:"unknown,omitempty"`
+	// Suggestions maps unknown attributes to the closest supported ones.
+	Suggestions map[string]string `json:"suggestions,omitempty"`
+	// Error is set by CompareSchemas if the menu couldn't be compared.
+	Error string `json:"error,omitempty"`
+}
+
+// InspectMenu discovers commands of a menu (e.g. "/ip/dhcp-server/lease")
+// and their arguments by walking /console/inspect.
+func (c *Client) InspectMenu(menu string) (*MenuSchema, error) {
+	log.Printf("[D][I] InspectMenu(%v)", menu)
+
+	children, err := c.inspectChildren(menu)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &MenuSchema{
+		Menu:     menu,
+		Commands: make(map[string][]string),
+	}
+
+	for name, nodeType := range children {
+		switch nodeType {
+		case "dir", "path":
+			schema.Menus = append(schema.Menus, name)
+		case "cmd":
+			args, err := c.inspectChildren(menu + "/" + name)
+			if err != nil {
+				return nil, err
+			}
+
+			names := []string{}
+			for arg, t := range args {
+				if t == "arg" {
+					names = append(names, arg)
+				}
+			}
+			sort.Strings(names)
+
+			schema.Commands[name] = names
+		}
+	}
+	sort.Strings(schema.Menus)
+
+	return schema, nil
+}
+
+// inspectChildren returns node types of children of the path by their names.
+func (c *Client) inspectChildren(path string) (map[string]string, error) {
+	cmd := fmt.Sprintf("/console/inspect =request=child =path=%v", strings.Join(strings.Split(strings.Trim(path, "/"), "/"), ","))
+	log.Printf("[D][I][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][I][<-] error: %v", err)
+		return nil, err
+	}
+	log.Printf("[D][I][<-] %v | %v", r.Re, r.Done)
+
+	children := make(map[string]string, len(r.Re))
+	for _, re := range r.Re {
+		if re.Map["type"] == "child" {
+			children[re.Map["name"]] = re.Map["node-type"]
+		}
+	}
+
+	return children, nil
+}
+
+// CompareSchema compares attributes of the resource type with arguments of
+// `add` and `set` commands of its menu on the connected RouterOS.
+func (c *Client) CompareSchema(res Resource) (*SchemaDiff, error) {
+	menu := getResourceMenu(res)
+
+	schema, err := c.InspectMenu(menu)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schema.Commands) == 0 {
+		return nil, fmt.Errorf("menu %v does not exist", menu)
+	}
+
+	supported := make(map[string]bool)
+	for _, cmd := range []string{"add", "set"} {
+		for _, arg := range schema.Commands[cmd] {
+			if !inspectIgnoredArgs[arg] {
+				supported[arg] = true
+			}
+		}
+	}
+
+	modeled := make(map[string]bool)
+	for _, f := range getFields(res) {
+		name := f.name
+		if v6, ok := f.options["v6"]; ok && !c.version.IsZero() && c.version.Major < 7 {
+			name = v6
+		}
+
+		if !inspectIgnoredArgs[name] && !f.readOnly() {
+			modeled[name] = true
+		}
+	}
+
+	diff := &SchemaDiff{Menu: menu}
+
+	for arg := range supported {
+		if !modeled[arg] {
+			diff.Missing = append(diff.Missing, arg)
+		}
+	}
+	sort.Strings(diff.Missing)
+
+	for attr := range modeled {
+		if !supported[attr] {
+			diff.Unknown = append(diff.Unknown, attr)
+		}
+	}
+	sort.Strings(diff.Unknown)
+
+	for _, attr := range diff.Unknown {
+		best, bestDist := "", len(attr)/2+1
+		for _, arg := range diff.Missing {
+			if d := levenshtein(attr, arg); d < bestDist {
+				best, bestDist = arg, d
+			}
+		}
+
+		if best != "" {
+			if diff.Suggestions == nil {
+				diff.Suggestions = make(map[string]string)
+			}
+			diff.Suggestions[attr] = best
+		}
+	}
+
+	return diff, nil
+}
+
+// CompareSchemas compares all resource types known to the library, except
+// ones not supported by the connected version. Menus which couldn't be
+// compared are reported by Error of their diffs.
+func (c *Client) CompareSchemas() ([]*SchemaDiff, error) {
+	var diffs []*SchemaDiff
+
+	for _, rt := range resourceTypes {
+		if err := c.checkVersion(rt); err != nil {
+			log.Printf("[D][I] skipping %v: %v", getResourceMenu(rt), err)
+			continue
+		}
+
+		diff, err := c.CompareSchema(rt)
+		if err != nil {
+			diff = &SchemaDiff{Menu: getResourceMenu(rt), Error: err.Error()}
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = prev[j] + 1
+			if d := cur[j-1] + 1; d < cur[j] {
+				cur[j] = d
+			}
+			if d := prev[j-1] + cost; d < cur[j] {
+				cur[j] = d
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
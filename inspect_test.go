@@ -0,0 +1,140 @@
+package routerosclient
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testInspectNodes are children of /console/inspect paths of a RouterOS
+// having only /ip/dns/static menu.
+var testInspectNodes = map[string]map[string]string{
+	"ip,dns,static": {
+		"add":    "cmd",
+		"set":    "cmd",
+		"print":  "cmd",
+		"remove": "cmd",
+	},
+	"ip,dns,static,add": {
+		"address":   "arg",
+		"comment":   "arg",
+		"copy-from": "arg",
+		"disabled":  "arg",
+		"name":      "arg",
+		"regexp":    "arg",
+		"ttl":       "arg",
+		"type":      "arg",
+	},
+	"ip,dns,static,set": {
+		"numbers":  "arg",
+		"address":  "arg",
+		"comment":  "arg",
+		"disabled": "arg",
+		"name":     "arg",
+		"regexp":   "arg",
+		"ttl":      "arg",
+		"type":     "arg",
+	},
+	"ip,dns,static,print": {
+		"where": "arg",
+	},
+	"ip,dns,static,remove": {
+		"numbers": "arg",
+	},
+}
+
+func newFakeInspectRouter(t *testing.T) *FakeRouterOS {
+	return newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		if words[0] == "/console/inspect" {
+			for _, w := range words {
+				if strings.HasPrefix(w, "=path=") {
+					for name, typ := range testInspectNodes[strings.TrimPrefix(w, "=path=")] {
+						r.Re(map[string]string{"type": "child", "name": name, "node-type": typ})
+					}
+				}
+			}
+		}
+		r.Done(nil)
+	})
+}
+
+func TestCompareSchema(t *testing.T) {
+	c := getFakeClient(t, newFakeInspectRouter(t))
+
+	schema, err := c.InspectMenu("/ip/dns/static")
+	if err != nil {
+		t.Fatalf("expected schema, got error: %v", err)
+	}
+	if args := schema.Commands["remove"]; !reflect.DeepEqual(args, []string{"numbers"}) {
+		t.Errorf("expected remove arguments, got %v", args)
+	}
+
+	if _, err := c.CompareSchema(&ResourceDHCPServerLease{}); err == nil {
+		t.Errorf("expected error for unknown menu, got nil")
+	}
+
+	diff, err := c.CompareSchema(&ResourceDNSStaticRecord{})
+	if err != nil {
+		t.Fatalf("expected diff, got error: %v", err)
+	}
+
+	expected := &SchemaDiff{
+		Menu:    "/ip/dns/static",
+		Missing: []string{"regexp", "type"},
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("expected %+v, got %+v", expected, diff)
+	}
+}
+
+func TestCompareSchemas(t *testing.T) {
+	f := newFakeInspectRouter(t)
+	f.Version = "6.40.9 (long-term)"
+	c := getFakeClient(t, f)
+
+	diffs, err := c.CompareSchemas()
+	if err != nil {
+		t.Fatalf("expected diffs, got error: %v", err)
+	}
+
+	byMenu := make(map[string]*SchemaDiff)
+	for _, d := range diffs {
+		byMenu[d.Menu] = d
+	}
+
+	if d := byMenu["/ip/dns/static"]; d == nil || d.Error != "" || len(d.Missing) != 2 {
+		t.Errorf("expected /ip/dns/static compared, got %+v", d)
+	}
+
+	if d := byMenu["/ip/dhcp-server/lease"]; d == nil || d.Error == "" {
+		t.Errorf("expected error of missing /ip/dhcp-server/lease, got %+v", d)
+	}
+
+	if d, ok := byMenu["/interface/bridge/vlan"]; ok {
+		t.Errorf("expected /interface/bridge/vlan unsupported by 6.40 skipped, got %+v", d)
+	}
+
+	for _, words := range f.Sentences() {
+		if strings.Contains(strings.Join(words, " "), "=path=interface,bridge,vlan") {
+			t.Errorf("expected /interface/bridge/vlan not inspected, got %v", words)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		d    int
+	}{
+		{"", "", 0},
+		{"mac-address", "mac-address", 0},
+		{"block-access", "blocked", 6},
+		{"admin-mac", "admin-mack", 1},
+	}
+
+	for _, tt := range tests {
+		if d := levenshtein(tt.a, tt.b); d != tt.d {
+			t.Errorf("%v/%v: expected %v, got %v", tt.a, tt.b, tt.d, d)
+		}
+	}
+}
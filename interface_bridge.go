@@ -1,30 +1,38 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
 package routerosclient
 
 import (
+	"time"
+
 	"github.com/asaskevich/govalidator"
 )
 
-/*
- * TODO:
- * admin-mac --
- * ageing-time -- Time the information about host will be kept in the the data base
- * arp -- Address Resolution Protocol
- * arp-timeout --
- * auto-mac --
- * max-message-age -- Time to remember Hello messages received from other bridges
- * priority -- Bridge interface priority
- * protocol-mode --
- * transmit-hold-count --
- */
-
+// ResourceInterfaceBridge is a bridge interface.
+// Nil AutoMAC, DHCPSnooping, FastForward, IGMPSnooping, VLANFiltering and
+// Priority, as well as zero TransmitHoldCount, are considered unset.
+// ARPTimeout is either `auto` or a RouterOS time value, MTU is either
+// `auto` or a number of bytes.
 type ResourceInterfaceBridge struct {
-	ID           string `ros:".id"`
-	Comment      string `ros:"comment"       valid:"optional"`
-	Disabled     bool   `ros:"disabled"      valid:"optional"`
-	FastForward  bool   `ros:"fast-forward"  valid:"optional"`
-	ForwardDelay string `ros:"forward-delay" valid:"optional"`
-	MTU          int    `ros:"mtu"           valid:"optional"`
-	Name         string `ros:"name"          valid:"required"`
+	ID                string             `ros:".id"`
+	AdminMAC          string             `ros:"admin-mac"               valid:"mac,optional"`
+	AgeingTime        time.Duration      `ros:"ageing-time"             valid:"optional"`
+	ARP               ARPMode            `ros:"arp"                     valid:"in(enabled|disabled|proxy-arp|reply-only|local-proxy-arp),optional"`
+	ARPTimeout        string             `ros:"arp-timeout"             valid:"optional"`
+	AutoMAC           *bool              `ros:"auto-mac"                valid:"optional"`
+	Comment           string             `ros:"comment"                 valid:"optional"`
+	DHCPSnooping      *bool              `ros:"dhcp-snooping,min=6.43"  valid:"optional"`
+	Disabled          bool               `ros:"disabled"                valid:"optional"`
+	FastForward       *bool              `ros:"fast-forward"            valid:"optional"`
+	ForwardDelay      time.Duration      `ros:"forward-delay"           valid:"optional"`
+	IGMPSnooping      *bool              `ros:"igmp-snooping,min=6.41"  valid:"optional"`
+	MaxMessageAge     time.Duration      `ros:"max-message-age"         valid:"optional"`
+	MTU               string             `ros:"mtu"                     valid:"optional"`
+	Name              string             `ros:"name"                    valid:"required"`
+	Priority          *int               `ros:"priority"                valid:"range(0|65535),optional"`
+	ProtocolMode      BridgeProtocolMode `ros:"protocol-mode"           valid:"in(none|stp|rstp|mstp),optional"`
+	TransmitHoldCount int                `ros:"transmit-hold-count"     valid:"range(1|10),optional"`
+	VLANFiltering     *bool              `ros:"vlan-filtering,min=6.41" valid:"optional"`
 }
 
 func (d *ResourceInterfaceBridge) validate() error {
@@ -35,6 +43,7 @@ func (d *ResourceInterfaceBridge) validate() error {
 			return err
 		}
 
+		return d.check()
 	}
 
 	return nil
@@ -48,6 +57,10 @@ func (d *ResourceInterfaceBridge) setID(id string) {
 	d.ID = id
 }
 
+func (*ResourceInterfaceBridge) getKeys() []string {
+	return []string{"name"}
+}
+
 func (*ResourceInterfaceBridge) getCreateCommand() string {
 	return "/interface/bridge/add"
 }
@@ -0,0 +1,31 @@
+package routerosclient
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+func (d *ResourceInterfaceBridge) check() error {
+	if d.ARPTimeout != "" && d.ARPTimeout != "auto" {
+		if _, err := parseDuration(d.ARPTimeout); err != nil {
+			return fmt.Errorf("arp-timeout: %v", err)
+		}
+	}
+
+	if d.MTU != "" && d.MTU != "auto" {
+		if mtu, err := strconv.Atoi(d.MTU); err != nil || mtu < 1 {
+			return fmt.Errorf("mtu: must be auto or a positive number, got %q", d.MTU)
+		}
+	}
+
+	if d.ForwardDelay != 0 && (d.ForwardDelay < 4*time.Second || d.ForwardDelay > 30*time.Second) {
+		return fmt.Errorf("forward-delay: must be between 4s and 30s")
+	}
+
+	if d.MaxMessageAge != 0 && (d.MaxMessageAge < 6*time.Second || d.MaxMessageAge > 40*time.Second) {
+		return fmt.Errorf("max-message-age: must be between 6s and 40s")
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceInterfaceBridgePort is a port of a bridge. The bridge must exist
+// before the port is created.
+// Horizon is either `none` or a number.
+// Nil IngressFiltering is unset, its RouterOS default differs by version.
+type ResourceInterfaceBridgePort struct {
+	ID               string           `ros:".id"`
+	Bridge           string           `ros:"bridge"                     valid:"required"`
+	Comment          string           `ros:"comment"                    valid:"optional"`
+	Disabled         bool             `ros:"disabled"                   valid:"optional"`
+	Edge             string           `ros:"edge"                       valid:"in(auto|no|no-discover|yes|yes-discover),optional"`
+	FrameTypes       BridgeFrameTypes `ros:"frame-types,min=6.41"       valid:"in(admit-all|admit-only-untagged-and-priority-tagged|admit-only-vlan-tagged),optional"`
+	Horizon          string           `ros:"horizon"                    valid:"optional"`
+	IngressFiltering *bool            `ros:"ingress-filtering,min=6.41" valid:"optional"`
+	Interface        string           `ros:"interface"                  valid:"required"`
+	PathCost         int              `ros:"path-cost"                  valid:"range(1|200000000),optional"`
+	PointToPoint     string           `ros:"point-to-point"             valid:"in(auto|yes|no),optional"`
+	Priority         int              `ros:"priority"                   valid:"range(0|240),optional"`
+	PVID             int              `ros:"pvid,min=6.41"              valid:"range(1|4094),optional"`
+}
+
+func (d *ResourceInterfaceBridgePort) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceInterfaceBridgePort) getID() string {
+	return d.ID
+}
+
+func (d *ResourceInterfaceBridgePort) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceInterfaceBridgePort) getKeys() []string {
+	return []string{"interface"}
+}
+
+func (d *ResourceInterfaceBridgePort) getReferences() []Reference {
+	var refs []Reference
+
+	refs = append(refs, newStrictReferences("/interface/bridge", "name", d.Bridge)...)
+	refs = append(refs, newReferences("/interface", "name", d.Interface)...)
+
+	return refs
+}
+
+func (*ResourceInterfaceBridgePort) getCreateCommand() string {
+	return "/interface/bridge/port/add"
+}
+
+func (*ResourceInterfaceBridgePort) getReadCommand() string {
+	return "/interface/bridge/port/print"
+}
+
+func (*ResourceInterfaceBridgePort) getUpdateCommand() string {
+	return "/interface/bridge/port/set"
+}
+
+func (*ResourceInterfaceBridgePort) getDeleteCommand() string {
+	return "/interface/bridge/port/remove"
+}
@@ -0,0 +1,16 @@
+package routerosclient
+
+import (
+	"fmt"
+	"strconv"
+)
+
+func (d *ResourceInterfaceBridgePort) check() error {
+	if d.Horizon != "" && d.Horizon != "none" {
+		if _, err := strconv.ParseUint(d.Horizon, 10, 32); err != nil {
+			return fmt.Errorf("horizon: must be `none` or a number")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceInterfaceBridgeVLAN is an entry of the bridge VLAN table.
+// VLANIDs are VLAN IDs or ranges of them, e.g. []string{"10", "20-29"}.
+// See BuildBridgeVLANs for deriving the table from port memberships.
+type ResourceInterfaceBridgeVLAN struct {
+	ID       string   `ros:".id"`
+	Bridge   string   `ros:"bridge"   valid:"required"`
+	Comment  string   `ros:"comment"  valid:"optional"`
+	Disabled bool     `ros:"disabled" valid:"optional"`
+	Tagged   []string `ros:"tagged"   valid:"optional"`
+	Untagged []string `ros:"untagged" valid:"optional"`
+	VLANIDs  []string `ros:"vlan-ids" valid:"required"`
+}
+
+func (d *ResourceInterfaceBridgeVLAN) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceInterfaceBridgeVLAN) getID() string {
+	return d.ID
+}
+
+func (d *ResourceInterfaceBridgeVLAN) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceInterfaceBridgeVLAN) getKeys() []string {
+	return []string{"bridge", "vlan-ids"}
+}
+
+func (*ResourceInterfaceBridgeVLAN) getVersionRange() (string, string) {
+	return "6.41", ""
+}
+
+func (d *ResourceInterfaceBridgeVLAN) getReferences() []Reference {
+	var refs []Reference
+
+	refs = append(refs, newStrictReferences("/interface/bridge", "name", d.Bridge)...)
+	refs = append(refs, newReferences("/interface", "name", d.Tagged...)...)
+	refs = append(refs, newReferences("/interface", "name", d.Untagged...)...)
+
+	return refs
+}
+
+func (*ResourceInterfaceBridgeVLAN) getCreateCommand() string {
+	return "/interface/bridge/vlan/add"
+}
+
+func (*ResourceInterfaceBridgeVLAN) getReadCommand() string {
+	return "/interface/bridge/vlan/print"
+}
+
+func (*ResourceInterfaceBridgeVLAN) getUpdateCommand() string {
+	return "/interface/bridge/vlan/set"
+}
+
+func (*ResourceInterfaceBridgeVLAN) getDeleteCommand() string {
+	return "/interface/bridge/vlan/remove"
+}
@@ -0,0 +1,24 @@
+package routerosclient
+
+import (
+	"fmt"
+)
+
+func (d *ResourceInterfaceBridgeVLAN) check() error {
+	if _, err := parseVLANIDs(d.VLANIDs); err != nil {
+		return fmt.Errorf("vlan-ids: %v", err)
+	}
+
+	untagged := make(map[string]bool)
+	for _, i := range d.Untagged {
+		untagged[i] = true
+	}
+
+	for _, i := range d.Tagged {
+		if untagged[i] {
+			return fmt.Errorf("interface %v is both tagged and untagged", i)
+		}
+	}
+
+	return nil
+}
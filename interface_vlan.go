@@ -0,0 +1,66 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceInterfaceVLAN is a VLAN interface on top of another interface.
+// Running is reported by RouterOS and never written.
+type ResourceInterfaceVLAN struct {
+	ID        string  `ros:".id"`
+	ARP       ARPMode `ros:"arp"       valid:"in(enabled|disabled|proxy-arp|reply-only|local-proxy-arp),optional"`
+	Comment   string  `ros:"comment"   valid:"optional"`
+	Disabled  bool    `ros:"disabled"  valid:"optional"`
+	Interface string  `ros:"interface" valid:"required"`
+	MTU       int     `ros:"mtu"       valid:"optional"`
+	Name      string  `ros:"name"      valid:"required"`
+	Running   bool    `ros:"running,readonly"`
+	VLANID    int     `ros:"vlan-id"   valid:"range(1|4094),required"`
+}
+
+func (d *ResourceInterfaceVLAN) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func (d *ResourceInterfaceVLAN) getID() string {
+	return d.ID
+}
+
+func (d *ResourceInterfaceVLAN) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceInterfaceVLAN) getKeys() []string {
+	return []string{"name"}
+}
+
+func (d *ResourceInterfaceVLAN) getReferences() []Reference {
+	return newReferences("/interface", "name", d.Interface)
+}
+
+func (*ResourceInterfaceVLAN) getCreateCommand() string {
+	return "/interface/vlan/add"
+}
+
+func (*ResourceInterfaceVLAN) getReadCommand() string {
+	return "/interface/vlan/print"
+}
+
+func (*ResourceInterfaceVLAN) getUpdateCommand() string {
+	return "/interface/vlan/set"
+}
+
+func (*ResourceInterfaceVLAN) getDeleteCommand() string {
+	return "/interface/vlan/remove"
+}
@@ -0,0 +1,397 @@
+// Command resourcegen generates resource types from a compact spec.
+//
+// Usage:
+//
+//	go run ./internal/resourcegen -spec resources.yaml
+//
+// For every resource described in the spec a file is generated containing
+// the resource struct and its methods: validate, getID, setID, getKeys,
+// getReferences and command getters. Also the registry of resource types is
+// generated. See resources.yaml for the spec format.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes a resource type.
+type Spec struct {
+	Type       string      `yaml:"type"`
+	File       string      `yaml:"file"`
+	Menu       string      `yaml:"menu"`
+	Doc        string      `yaml:"doc"`
+	Keys       []string    `yaml:"keys"`
+	Fields     []Field     `yaml:"fields"`
+	References []Reference `yaml:"references"`
+	// Min and Max limit versions of RouterOS supporting the menu.
+	Min string `yaml:"min"`
+	Max string `yaml:"max"`
+	// Check adds a call of hand-written `check() error` method to validate,
+	// for validation rules which can't be expressed with govalidator tags.
+	Check bool `yaml:"check"`
+	// Ordered adds getMoveCommand for menus where order of entries matters,
+	// e.g. firewall rules.
+	Ordered bool `yaml:"ordered"`
+}
+
+// Field describes a field of a resource struct.
+type Field struct {
+	Name  string `yaml:"name"`
+	Type  string `yaml:"type"`
+	Ros   string `yaml:"ros"`
+	Valid string `yaml:"valid"`
+}
+
+// Reference describes a reference to another resource stored in a field.
+type Reference struct {
+	Field string `yaml:"field"`
+	Menu  string `yaml:"menu"`
+	Key   string `yaml:"key"`
+	// Split is a separator of a list of names stored in a string field.
+	Split string `yaml:"split"`
+	// Skip lists special values which don't refer to anything.
+	Skip []string `yaml:"skip"`
+	// Strict references are checked by CreateResource.
+	Strict bool `yaml:"strict"`
+}
+
+// File is the spec file.
+type File struct {
+	Resources []Spec `yaml:"resources"`
+}
+
+const header = "// Code generated by resourcegen from %v; DO NOT EDIT.\n\n"
+
+var resourceTemplate = template.Must(template.New("resource").Funcs(template.FuncMap{
+	"comment": comment,
+	"quote":   func(s string) string { return fmt.Sprintf("%q", s) },
+	"join":    strings.Join,
+}).Parse(`package routerosclient
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+{{if .Imports}}
+{{end}}
+	"github.com/asaskevich/govalidator"
+)
+
+{{comment .Doc}}type {{.Type}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+
+func (d *{{.Type}}) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+{{if .Check}}
+		return d.check()
+{{- end}}
+	}
+
+	return nil
+}
+
+func (d *{{.Type}}) getID() string {
+	return d.ID
+}
+
+func (d *{{.Type}}) setID(id string) {
+	d.ID = id
+}
+
+func (*{{.Type}}) getKeys() []string {
+	return {{if .Keys}}[]string{ {{- join .QuotedKeys ", " -}} }{{else}}nil{{end}}
+}
+{{if or .Min .Max}}
+func (*{{.Type}}) getVersionRange() (string, string) {
+	return {{quote .Min}}, {{quote .Max}}
+}
+{{end}}
+{{- if .SingleReference}}
+func (d *{{.Type}}) getReferences() []Reference {
+	return {{.SingleReference.Func}}({{quote .SingleReference.Menu}}, {{quote .SingleReference.Key}}, {{.SingleReference.Values}})
+}
+{{else if .References}}
+func (d *{{.Type}}) getReferences() []Reference {
+	var refs []Reference
+{{range .References}}
+{{- if .Skip}}
+	if {{.SkipCond}} {
+		refs = append(refs, {{.Func}}({{quote .Menu}}, {{quote .Key}}, {{.Values}})...)
+	}
+{{- else}}
+	refs = append(refs, {{.Func}}({{quote .Menu}}, {{quote .Key}}, {{.Values}})...)
+{{- end}}
+{{- end}}
+
+	return refs
+}
+{{end}}
+func (*{{.Type}}) getCreateCommand() string {
+	return "{{.Menu}}/add"
+}
+
+func (*{{.Type}}) getReadCommand() string {
+	return "{{.Menu}}/print"
+}
+
+func (*{{.Type}}) getUpdateCommand() string {
+	return "{{.Menu}}/set"
+}
+
+func (*{{.Type}}) getDeleteCommand() string {
+	return "{{.Menu}}/remove"
+}
+{{- if .Ordered}}
+
+func (*{{.Type}}) getMoveCommand() string {
+	return "{{.Menu}}/move"
+}
+{{- end}}
+`))
+
+var registryTemplate = template.Must(template.New("registry").Parse(`package routerosclient
+
+// resourceTypes lists all resource types known to the library.
+var resourceTypes = []Resource{
+{{- range .}}
+	&{{.Type}}{},
+{{- end}}
+}
+`))
+
+type resourceData struct {
+	Spec
+	Fields     []fieldData
+	References []referenceData
+	// SingleReference is set if there is the only reference without special values
+	SingleReference *referenceData
+	QuotedKeys      []string
+	Imports         []string
+}
+
+// packages maps package names allowed in field types to import paths.
+var packages = map[string]string{
+	"netip": "net/netip",
+	"time":  "time",
+}
+
+type fieldData struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+type referenceData struct {
+	Reference
+	Func     string
+	Values   string
+	SkipCond string
+}
+
+func comment(doc string) string {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return ""
+	}
+
+	b := &strings.Builder{}
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			b.WriteString("//\n")
+		} else {
+			b.WriteString("// " + line + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func prepare(s Spec) (*resourceData, error) {
+	if s.Type == "" || s.File == "" || s.Menu == "" {
+		return nil, fmt.Errorf("type, file and menu are required: %+v", s)
+	}
+
+	d := &resourceData{Spec: s}
+	fields := append([]Field{{Name: "ID", Type: "string", Ros: ".id"}}, s.Fields...)
+
+	// align valid tags in a column
+	width := 0
+	for _, f := range fields {
+		if l := len(f.Ros); f.Valid != "" && l > width {
+			width = l
+		}
+	}
+
+	goFields := make(map[string]Field)
+	attrs := make(map[string]bool)
+	imports := make(map[string]bool)
+
+	for _, f := range fields {
+		if f.Name == "" || f.Type == "" || f.Ros == "" {
+			return nil, fmt.Errorf("%v: name, type and ros are required for fields: %+v", s.Type, f)
+		}
+
+		tag := fmt.Sprintf(`ros:"%v"`, f.Ros)
+		if f.Valid != "" {
+			tag += strings.Repeat(" ", width-len(f.Ros)+1) + fmt.Sprintf(`valid:"%v"`, f.Valid)
+		}
+
+		if i := strings.Index(f.Type, "."); i >= 0 {
+			pkg := strings.TrimLeft(f.Type[:i], "[]*")
+			path, ok := packages[pkg]
+			if !ok {
+				return nil, fmt.Errorf("%v: unsupported package of field type: %v", s.Type, f.Type)
+			}
+			imports[path] = true
+		}
+
+		d.Fields = append(d.Fields, fieldData{Name: f.Name, Type: f.Type, Tag: "`" + tag + "`"})
+		goFields[f.Name] = f
+		attrs[strings.Split(f.Ros, ",")[0]] = true
+	}
+
+	for _, k := range s.Keys {
+		if !attrs[k] {
+			return nil, fmt.Errorf("%v: unknown key attribute: %v", s.Type, k)
+		}
+		d.QuotedKeys = append(d.QuotedKeys, fmt.Sprintf("%q", k))
+	}
+
+	for _, r := range s.References {
+		f, ok := goFields[r.Field]
+		if !ok {
+			return nil, fmt.Errorf("%v: unknown reference field: %v", s.Type, r.Field)
+		}
+
+		rd := referenceData{Reference: r, Func: "newReferences"}
+		if r.Strict {
+			rd.Func = "newStrictReferences"
+		}
+		value := "d." + r.Field
+
+		switch {
+		case r.Split != "":
+			rd.Values = fmt.Sprintf("strings.Split(%v, %q)...", value, r.Split)
+			imports["strings"] = true
+		case strings.HasPrefix(f.Type, "[]"):
+			rd.Values = value + "..."
+		default:
+			rd.Values = value
+		}
+
+		var conds []string
+		for _, v := range r.Skip {
+			conds = append(conds, fmt.Sprintf("%v != %q", value, v))
+		}
+		rd.SkipCond = strings.Join(conds, " && ")
+
+		d.References = append(d.References, rd)
+	}
+
+	for path := range imports {
+		d.Imports = append(d.Imports, path)
+	}
+	sort.Strings(d.Imports)
+
+	if len(d.References) == 1 && d.References[0].SkipCond == "" {
+		d.SingleReference = &d.References[0]
+	}
+
+	return d, nil
+}
+
+func render(specPath string, tmpl *template.Template, data interface{}) ([]byte, error) {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, header, filepath.Base(specPath))
+
+	if err := tmpl.Execute(b, data); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, b.Bytes())
+	}
+
+	return src, nil
+}
+
+func readSpec(path string) (*File, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec File
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+
+	return &spec, nil
+}
+
+// generate returns generated sources by file names.
+func generate(specPath string) (map[string][]byte, error) {
+	spec, err := readSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+
+	for _, s := range spec.Resources {
+		d, err := prepare(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if files[s.File], err = render(specPath, resourceTemplate, d); err != nil {
+			return nil, fmt.Errorf("%v: %v", s.File, err)
+		}
+	}
+
+	if files["resource_types.go"], err = render(specPath, registryTemplate, spec.Resources); err != nil {
+		return nil, fmt.Errorf("resource_types.go: %v", err)
+	}
+
+	return files, nil
+}
+
+func main() {
+	specPath := flag.String("spec", "resources.yaml", "path to the spec file")
+	outDir := flag.String("out", ".", "output directory")
+	flag.Parse()
+
+	files, err := generate(*specPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(*outDir, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGeneratedUpToDate makes sure generated files match the spec.
+func TestGeneratedUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	files, err := generate(filepath.Join(root, "resources.yaml"))
+	if err != nil {
+		t.Fatalf("expected files generated, got error: %v", err)
+	}
+
+	for name, src := range files {
+		b, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Errorf("%v: %v", name, err)
+			continue
+		}
+
+		if !bytes.Equal(b, src) {
+			t.Errorf("%v is out of date, run `go generate`", name)
+		}
+	}
+}
+
+func TestPrepareInvalid(t *testing.T) {
+	tests := map[string]Spec{
+		"no menu": {Type: "ResourceX", File: "x.go"},
+		"unknown key": {
+			Type: "ResourceX", File: "x.go", Menu: "/x",
+			Keys: []string{"name"},
+		},
+		"unknown reference": {
+			Type: "ResourceX", File: "x.go", Menu: "/x",
+			References: []Reference{{Field: "Interface", Menu: "/interface", Key: "name"}},
+		},
+		"unsupported package": {
+			Type: "ResourceX", File: "x.go", Menu: "/x",
+			Fields: []Field{{Name: "Addr", Type: "net.IP", Ros: "address"}},
+		},
+		"incomplete field": {
+			Type: "ResourceX", File: "x.go", Menu: "/x",
+			Fields: []Field{{Name: "Name", Ros: "name"}},
+		},
+	}
+
+	for name, spec := range tests {
+		if _, err := prepare(spec); err == nil {
+			t.Errorf("%v: expected error, got nil", name)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	d, err := prepare(Spec{
+		Type:  "ResourceX",
+		File:  "x.go",
+		Menu:  "/x",
+		Min:   "7.0",
+		Check: true,
+		Keys:  []string{"name"},
+		Fields: []Field{
+			{Name: "Name", Type: "string", Ros: "name", Valid: "required"},
+			{Name: "Ports", Type: "[]string", Ros: "ports,min=7.1", Valid: "optional"},
+		},
+		References: []Reference{
+			{Field: "Ports", Menu: "/interface", Key: "name"},
+			{Field: "Name", Menu: "/x", Key: "name", Skip: []string{"none"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected spec prepared, got error: %v", err)
+	}
+
+	src, err := render("x.yaml", resourceTemplate, d)
+	if err != nil {
+		t.Fatalf("expected source rendered, got error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"return d.check()",
+		`return "7.0", ""`,
+		`newReferences("/interface", "name", d.Ports...)`,
+		`if d.Name != "none" {`,
+		"`ros:\"ports,min=7.1\" valid:\"optional\"`",
+	} {
+		if !bytes.Contains(src, []byte(expected)) {
+			t.Errorf("expected %q in generated source:\n%s", expected, src)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"net/netip"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceIPAddress is an IPv4 address assigned to an interface. Address
+// carries the address of the interface with prefix length of the network,
+// e.g. 192.168.88.1/24. Dynamic and Invalid are reported by RouterOS
+// and never written.
+type ResourceIPAddress struct {
+	ID        string       `ros:".id"`
+	Address   netip.Prefix `ros:"address"   valid:"required"`
+	Comment   string       `ros:"comment"   valid:"optional"`
+	Disabled  bool         `ros:"disabled"  valid:"optional"`
+	Dynamic   bool         `ros:"dynamic,readonly"`
+	Interface string       `ros:"interface" valid:"required"`
+	Invalid   bool         `ros:"invalid,readonly"`
+	Network   netip.Addr   `ros:"network"   valid:"optional"`
+}
+
+func (d *ResourceIPAddress) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceIPAddress) getID() string {
+	return d.ID
+}
+
+func (d *ResourceIPAddress) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceIPAddress) getKeys() []string {
+	return []string{"address", "interface"}
+}
+
+func (d *ResourceIPAddress) getReferences() []Reference {
+	return newReferences("/interface", "name", d.Interface)
+}
+
+func (*ResourceIPAddress) getCreateCommand() string {
+	return "/ip/address/add"
+}
+
+func (*ResourceIPAddress) getReadCommand() string {
+	return "/ip/address/print"
+}
+
+func (*ResourceIPAddress) getUpdateCommand() string {
+	return "/ip/address/set"
+}
+
+func (*ResourceIPAddress) getDeleteCommand() string {
+	return "/ip/address/remove"
+}
@@ -0,0 +1,17 @@
+package routerosclient
+
+import (
+	"fmt"
+)
+
+func (d *ResourceIPAddress) check() error {
+	if !d.Address.IsValid() || !d.Address.Addr().Is4() {
+		return fmt.Errorf("address: must be IPv4 address with prefix length")
+	}
+
+	if d.Network.IsValid() && !d.Network.Is4() {
+		return fmt.Errorf("network: must be IPv4 address")
+	}
+
+	return nil
+}
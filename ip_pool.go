@@ -0,0 +1,69 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceIPPool is a pool of IPv4 addresses. Ranges are single addresses,
+// ranges of them or networks, e.g. []string{"192.168.88.10-192.168.88.254"}.
+type ResourceIPPool struct {
+	ID       string   `ros:".id"`
+	Comment  string   `ros:"comment"   valid:"optional"`
+	Name     string   `ros:"name"      valid:"required"`
+	NextPool string   `ros:"next-pool" valid:"optional"`
+	Ranges   []string `ros:"ranges"    valid:"required"`
+}
+
+func (d *ResourceIPPool) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceIPPool) getID() string {
+	return d.ID
+}
+
+func (d *ResourceIPPool) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceIPPool) getKeys() []string {
+	return []string{"name"}
+}
+
+func (d *ResourceIPPool) getReferences() []Reference {
+	var refs []Reference
+
+	if d.NextPool != "none" {
+		refs = append(refs, newReferences("/ip/pool", "name", d.NextPool)...)
+	}
+
+	return refs
+}
+
+func (*ResourceIPPool) getCreateCommand() string {
+	return "/ip/pool/add"
+}
+
+func (*ResourceIPPool) getReadCommand() string {
+	return "/ip/pool/print"
+}
+
+func (*ResourceIPPool) getUpdateCommand() string {
+	return "/ip/pool/set"
+}
+
+func (*ResourceIPPool) getDeleteCommand() string {
+	return "/ip/pool/remove"
+}
@@ -0,0 +1,52 @@
+package routerosclient
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+func (d *ResourceIPPool) check() error {
+	for _, r := range d.Ranges {
+		if err := checkIPRange(r); err != nil {
+			return fmt.Errorf("ranges: %v", err)
+		}
+	}
+
+	if d.NextPool == d.Name {
+		return fmt.Errorf("next-pool: pool can't refer to itself")
+	}
+
+	return nil
+}
+
+// checkIPRange checks a range of IPv4 addresses, which is either a single
+// address, a network or a pair of addresses separated by `-`.
+func checkIPRange(r string) error {
+	if strings.Contains(r, "/") {
+		if p, err := netip.ParsePrefix(r); err != nil || !p.Addr().Is4() {
+			return fmt.Errorf("invalid network: %v", r)
+		}
+		return nil
+	}
+
+	bounds := strings.SplitN(r, "-", 2)
+
+	first, err := netip.ParseAddr(bounds[0])
+	if err != nil || !first.Is4() {
+		return fmt.Errorf("invalid address: %v", r)
+	}
+
+	if len(bounds) == 2 {
+		last, err := netip.ParseAddr(bounds[1])
+		if err != nil || !last.Is4() {
+			return fmt.Errorf("invalid address: %v", r)
+		}
+
+		if last.Less(first) {
+			return fmt.Errorf("invalid range: %v", r)
+		}
+	}
+
+	return nil
+}
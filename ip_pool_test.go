@@ -0,0 +1,45 @@
+package routerosclient
+
+import "testing"
+
+func TestValidateIPPool(t *testing.T) {
+	invalid := []*ResourceIPPool{
+		{Name: "pool1"},
+		{Name: "pool1", Ranges: []string{"192.168.0.10-192.168.0.1"}},
+		{Name: "pool1", Ranges: []string{"192.168.0.10-"}},
+		{Name: "pool1", Ranges: []string{"2001:db8::1-2001:db8::ff"}},
+		{Name: "pool1", Ranges: []string{"192.168.0.0/33"}},
+		{Name: "pool1", Ranges: []string{"192.168.0.1"}, NextPool: "pool1"},
+	}
+
+	for _, p := range invalid {
+		if err := p.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", p)
+		}
+	}
+
+	valid := &ResourceIPPool{
+		Name:     "pool1",
+		NextPool: "pool2",
+		Ranges:   []string{"192.168.0.10-192.168.0.20", "192.168.0.30", "192.168.1.0/24"},
+	}
+
+	if err := valid.validate(); err != nil {
+		t.Errorf("expected valid pool, got error: %v", err)
+	}
+
+	server := &ResourceDHCPServer{Name: "dhcp1", Interface: "br0", AddressPool: "pool1"}
+
+	sorted, err := SortResources([]Resource{server, &ResourceIPPool{Name: "pool2", Ranges: []string{"10.0.0.1"}}, valid})
+	if err != nil {
+		t.Fatalf("expected resources sorted, got error: %v", err)
+	}
+
+	if sorted[2] != server {
+		t.Errorf("expected DHCP server created after pools, got %v", sorted)
+	}
+
+	if err := (&ResourceDHCPServer{Name: "dhcp1", Interface: "br0", Authoritative: "maybe"}).validate(); err == nil {
+		t.Errorf("expected error for invalid authoritative, got nil")
+	}
+}
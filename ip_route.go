@@ -0,0 +1,72 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+import (
+	"net/netip"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// ResourceIPRoute is a static IPv4 route. Gateway is an address, an interface
+// or a comma separated list of them. RoutingTable is sent as `routing-mark`
+// to RouterOS 6. Unset RoutingTable matches routes RouterOS 7 reports
+// in `main` table.
+// Active and Dynamic are reported by RouterOS and never written.
+// Zero Distance, Scope and TargetScope are considered unset.
+type ResourceIPRoute struct {
+	ID           string       `ros:".id"`
+	Active       bool         `ros:"active,readonly"`
+	CheckGateway string       `ros:"check-gateway"                              valid:"in(arp|ping|bfd|bfd-multihop|none),optional"`
+	Comment      string       `ros:"comment"                                    valid:"optional"`
+	Disabled     bool         `ros:"disabled"                                   valid:"optional"`
+	Distance     int          `ros:"distance"                                   valid:"range(1|255),optional"`
+	DstAddress   netip.Prefix `ros:"dst-address"                                valid:"required"`
+	Dynamic      bool         `ros:"dynamic,readonly"`
+	Gateway      string       `ros:"gateway"                                    valid:"required"`
+	RoutingTable string       `ros:"routing-table,v6=routing-mark,default=main" valid:"optional"`
+	Scope        int          `ros:"scope"                                      valid:"range(0|255),optional"`
+	TargetScope  int          `ros:"target-scope"                               valid:"range(0|255),optional"`
+}
+
+func (d *ResourceIPRoute) validate() error {
+	if d.ID == "" {
+		_, err := govalidator.ValidateStruct(d)
+
+		if err != nil {
+			return err
+		}
+
+		return d.check()
+	}
+
+	return nil
+}
+
+func (d *ResourceIPRoute) getID() string {
+	return d.ID
+}
+
+func (d *ResourceIPRoute) setID(id string) {
+	d.ID = id
+}
+
+func (*ResourceIPRoute) getKeys() []string {
+	return []string{"dst-address", "gateway", "routing-table"}
+}
+
+func (*ResourceIPRoute) getCreateCommand() string {
+	return "/ip/route/add"
+}
+
+func (*ResourceIPRoute) getReadCommand() string {
+	return "/ip/route/print"
+}
+
+func (*ResourceIPRoute) getUpdateCommand() string {
+	return "/ip/route/set"
+}
+
+func (*ResourceIPRoute) getDeleteCommand() string {
+	return "/ip/route/remove"
+}
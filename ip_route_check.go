@@ -0,0 +1,28 @@
+package routerosclient
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+func (d *ResourceIPRoute) check() error {
+	if !d.DstAddress.IsValid() || !d.DstAddress.Addr().Is4() {
+		return fmt.Errorf("dst-address: must be IPv4 network")
+	}
+
+	for _, gw := range strings.Split(d.Gateway, ",") {
+		// address may be followed by an interface, e.g. 10.0.0.1%ether1
+		host := strings.SplitN(gw, "%", 2)[0]
+
+		if host == "" {
+			return fmt.Errorf("gateway: invalid value: %v", d.Gateway)
+		}
+
+		if addr, err := netip.ParseAddr(host); err == nil && !addr.Is4() {
+			return fmt.Errorf("gateway: must be IPv4 address or interface: %v", gw)
+		}
+	}
+
+	return nil
+}
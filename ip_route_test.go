@@ -0,0 +1,112 @@
+package routerosclient
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestValidateIPRoute(t *testing.T) {
+	dst := netip.MustParsePrefix("10.0.0.0/8")
+
+	invalid := []*ResourceIPRoute{
+		{Gateway: "192.168.88.254"},
+		{DstAddress: dst},
+		{DstAddress: netip.MustParsePrefix("2001:db8::/32"), Gateway: "ether1"},
+		{DstAddress: dst, Gateway: "2001:db8::1"},
+		{DstAddress: dst, Gateway: "192.168.88.254,"},
+		{DstAddress: dst, Gateway: "ether1", Distance: 256},
+		{DstAddress: dst, Gateway: "ether1", CheckGateway: "icmp"},
+	}
+
+	for _, r := range invalid {
+		if err := r.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", r)
+		}
+	}
+
+	for _, gw := range []string{"192.168.88.254", "ether1", "10.0.0.1%ether1", "10.0.0.1,10.0.0.2"} {
+		r := &ResourceIPRoute{DstAddress: dst, Gateway: gw}
+		if err := r.validate(); err != nil {
+			t.Errorf("%v: expected valid route, got error: %v", gw, err)
+		}
+	}
+}
+
+func TestEnsureRouteMainTable(t *testing.T) {
+	f := newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		if words[0] == "/ip/route/print" {
+			r.Re(map[string]string{
+				".id":           "*1",
+				"dst-address":   "10.0.0.0/8",
+				"gateway":       "192.168.88.254",
+				"routing-table": "main",
+				"distance":      "1",
+				"active":        "true",
+			})
+		}
+		r.Done(nil)
+	})
+	c := getFakeClient(t, f)
+
+	route := &ResourceIPRoute{DstAddress: netip.MustParsePrefix("10.0.0.0/8"), Gateway: "192.168.88.254"}
+
+	id, changed, err := c.EnsureResource(route)
+	if err != nil {
+		t.Fatalf("expected route ensured, got error: %v", err)
+	}
+	if id != "*1" || changed {
+		t.Errorf("expected existing route unchanged, got %v, %v", id, changed)
+	}
+
+	for _, cmd := range f.Commands() {
+		if cmd != "/ip/route/print" {
+			t.Errorf("expected only print, got %v", f.Commands())
+		}
+	}
+
+	report, err := c.DetectDrift([]Resource{route})
+	if err != nil {
+		t.Fatalf("expected report, got error: %v", err)
+	}
+	if report.HasDrift() {
+		t.Errorf("expected no drift, got %+v", report)
+	}
+}
+
+func TestDetectDriftRouteKeys(t *testing.T) {
+	f := newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		if words[0] == "/ip/route/print" {
+			r.Re(map[string]string{
+				".id":         "*1",
+				"dst-address": "10.0.0.0/8",
+				"gateway":     "192.168.88.254",
+			})
+		}
+		r.Done(nil)
+	})
+	c := getFakeClient(t, f)
+
+	route := &ResourceIPRoute{DstAddress: netip.MustParsePrefix("172.16.0.0/12"), Gateway: "192.168.88.254"}
+
+	report, err := c.DetectDrift([]Resource{route})
+	if err != nil {
+		t.Fatalf("expected report, got error: %v", err)
+	}
+
+	// keys of missing and extra routes are both completed by defaults
+	expected := &DriftReport{
+		Missing: []DriftEntry{{
+			Menu: "/ip/route",
+			Key:  map[string]string{"dst-address": "172.16.0.0/12", "gateway": "192.168.88.254", "routing-table": "main"},
+		}},
+		Extra: []DriftEntry{{
+			Menu: "/ip/route",
+			ID:   "*1",
+			Key:  map[string]string{"dst-address": "10.0.0.0/8", "gateway": "192.168.88.254", "routing-table": "main"},
+		}},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("expected %+v, got %+v", expected, report)
+	}
+}
@@ -0,0 +1,259 @@
+package routerosclient
+
+import (
+	"fmt"
+	"log"
+)
+
+// orderedResource is implemented by resources living in menus where order
+// of entries matters, e.g. firewall rules.
+type orderedResource interface {
+	Resource
+	getMoveCommand() string
+}
+
+func getOrderedResource(res Resource) (orderedResource, error) {
+	o, ok := res.(orderedResource)
+	if !ok {
+		return nil, fmt.Errorf("entries of %v are not ordered", getResourceMenu(res))
+	}
+
+	return o, nil
+}
+
+// resolveID returns id of the resource, reading it from RouterOS if not set.
+func (c *Client) resolveID(res Resource) (string, error) {
+	if res.getID() != "" {
+		return res.getID(), nil
+	}
+
+	cur, err := c.ReadResource(res)
+	if err != nil {
+		return "", err
+	}
+
+	return cur.getID(), nil
+}
+
+// CreateResourceBefore creates the resource placing it before the given one.
+// If before is nil, the resource is added to the end. Unlike CreateResource,
+// it doesn't check whether the same resource exists, since e.g. firewall
+// rules may legitimately repeat.
+func (c *Client) CreateResourceBefore(res Resource, before Resource) (string, error) {
+	log.Printf("[D][C] CreateResourceBefore(%v, %v)", res, before)
+
+	if _, err := getOrderedResource(res); err != nil {
+		return "", err
+	}
+
+	if err := res.validate(); err != nil {
+		return "", err
+	}
+
+	var extra map[string]string
+
+	if before != nil {
+		id, err := c.resolveID(before)
+		if err != nil {
+			return "", err
+		}
+		extra = map[string]string{"place-before": id}
+	}
+
+	return c.createResource(res, extra)
+}
+
+// MoveResource moves the resource before the given one. If before is nil,
+// the resource is moved to the end.
+func (c *Client) MoveResource(res Resource, before Resource) (error, bool) {
+	log.Printf("[D][M] MoveResource(%v, %v)", res, before)
+
+	o, err := getOrderedResource(res)
+	if err != nil {
+		return err, false
+	}
+
+	id, err := c.resolveID(res)
+	if err != nil {
+		return err, false
+	}
+
+	destination := ""
+	if before != nil {
+		if destination, err = c.resolveID(before); err != nil {
+			return err, false
+		}
+	}
+
+	return c.moveResource(o, id, destination)
+}
+
+func (c *Client) moveResource(o orderedResource, id, destination string) (error, bool) {
+	attrs := map[string]string{"numbers": id, "destination": destination}
+
+	cmd, err := buildCommand(o.getMoveCommand(), nil, &attrs, false)
+	if err != nil {
+		return err, false
+	}
+	log.Printf("[D][M][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][M][<-] error: %v", err)
+		return err, false
+	}
+	log.Printf("[D][M][<-] %v | %v", r.Re, r.Done)
+
+	return nil, true
+}
+
+// ReconcileResources makes the ordered list of resources of one menu on
+// RouterOS match the given one, e.g. firewall rules. Existing resources are
+// matched by all their attributes, missing ones are created at their
+// positions, unexpected ones are deleted and misplaced ones are moved.
+// Only chains present in the given list are reconciled, dynamic resources
+// are left untouched. Returns ids of resources in the given order.
+func (c *Client) ReconcileResources(res []Resource) ([]string, error) {
+	log.Printf("[D][~] ReconcileResources(%v)", res)
+
+	if len(res) == 0 {
+		return nil, nil
+	}
+
+	o, err := getOrderedResource(res[0])
+	if err != nil {
+		return nil, err
+	}
+
+	menu := getResourceMenu(o)
+	chains := make(map[string]bool)
+
+	for _, r := range res {
+		if getResourceMenu(r) != menu {
+			return nil, fmt.Errorf("resources of different menus: %v, %v", menu, getResourceMenu(r))
+		}
+
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+
+		attrs, err := buildAttrsFromResource(r)
+		if err != nil {
+			return nil, err
+		}
+		chains[attrs["chain"]] = true
+	}
+
+	reply, err := c.printResources(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var actual []Resource
+	for _, re := range reply.Re {
+		if re.Map["dynamic"] == "true" || !chains[re.Map["chain"]] {
+			continue
+		}
+
+		nr, err := newResource(o)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := setFieldsFromMap(nr, re.Map); err != nil {
+			return nil, err
+		}
+
+		actual = append(actual, nr)
+	}
+
+	// match expected resources with actual ones, attributes left unset are
+	// equal to RouterOS defaults
+	ids := make([]string, len(res))
+	matched := make([]bool, len(actual))
+
+	for i, r := range res {
+		for j, a := range actual {
+			if !matched[j] && len(diffResources(r, a)) == 0 && len(diffResources(a, r)) == 0 {
+				matched[j] = true
+				ids[i] = a.getID()
+				break
+			}
+		}
+	}
+
+	// order keeps ids of expected resources in their current order
+	var order []string
+
+	for j, a := range actual {
+		if matched[j] {
+			order = append(order, a.getID())
+			continue
+		}
+
+		if err, _ := c.deleteResource(a, a.getID()); err != nil {
+			return nil, err
+		}
+	}
+
+	// place resources from the last one, so every resource has its
+	// successor in place already
+	next := ""
+
+	for i := len(res) - 1; i >= 0; i-- {
+		if ids[i] == "" {
+			var extra map[string]string
+			if next != "" {
+				extra = map[string]string{"place-before": next}
+			}
+
+			id, err := c.createResource(res[i], extra)
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+			order = insertBefore(order, id, next)
+		} else if successor(order, ids[i]) != next {
+			if err, _ := c.moveResource(o, ids[i], next); err != nil {
+				return nil, err
+			}
+			order = insertBefore(remove(order, ids[i]), ids[i], next)
+		}
+
+		next = ids[i]
+	}
+
+	return ids, nil
+}
+
+// successor returns id following the given one in order, or "" if it's the last one.
+func successor(order []string, id string) string {
+	for i, v := range order {
+		if v == id && i+1 < len(order) {
+			return order[i+1]
+		}
+	}
+
+	return ""
+}
+
+// insertBefore inserts id before next, or to the end if next is "".
+func insertBefore(order []string, id, next string) []string {
+	for i, v := range order {
+		if next != "" && v == next {
+			return append(order[:i], append([]string{id}, order[i:]...)...)
+		}
+	}
+
+	return append(order, id)
+}
+
+func remove(order []string, id string) []string {
+	for i, v := range order {
+		if v == id {
+			return append(order[:i:i], order[i+1:]...)
+		}
+	}
+
+	return order
+}
@@ -0,0 +1,299 @@
+package routerosclient
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRuleTable emulates an ordered RouterOS menu, e.g. /ip/firewall/filter.
+type fakeRuleTable struct {
+	mu       sync.Mutex
+	menu     string
+	rules    []map[string]string
+	next     int
+	defaults map[string]string // reported for attributes not given to add
+}
+
+func newFakeRuleTable(menu string, rules ...map[string]string) *fakeRuleTable {
+	tbl := &fakeRuleTable{menu: menu, next: len(rules) + 1}
+	for i, r := range rules {
+		r[".id"] = fmt.Sprintf("*%v", i+1)
+		tbl.rules = append(tbl.rules, r)
+	}
+
+	return tbl
+}
+
+func (tbl *fakeRuleTable) index(id string) int {
+	for i, r := range tbl.rules {
+		if r[".id"] == id {
+			return i
+		}
+	}
+
+	return len(tbl.rules)
+}
+
+func (tbl *fakeRuleTable) insert(rule map[string]string, before string) {
+	i := tbl.index(before)
+	tbl.rules = append(tbl.rules[:i], append([]map[string]string{rule}, tbl.rules[i:]...)...)
+}
+
+func (tbl *fakeRuleTable) handle(r *FakeReply, words []string) {
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+
+	attrs := make(map[string]string)
+	for _, w := range words[1:] {
+		if kv := strings.SplitN(strings.TrimPrefix(w, "="), "=", 2); len(kv) == 2 && strings.HasPrefix(w, "=") {
+			attrs[kv[0]] = kv[1]
+		}
+	}
+
+	switch strings.TrimPrefix(words[0], tbl.menu) {
+	case "/print":
+		for _, rule := range tbl.rules {
+			r.Re(rule)
+		}
+		r.Done(nil)
+	case "/add":
+		before := attrs["place-before"]
+		delete(attrs, "place-before")
+		for k, v := range tbl.defaults {
+			if _, ok := attrs[k]; !ok {
+				attrs[k] = v
+			}
+		}
+		attrs[".id"] = fmt.Sprintf("*%v", tbl.next)
+		tbl.next++
+		tbl.insert(attrs, before)
+		r.Done(map[string]string{"ret": attrs[".id"]})
+	case "/move":
+		i := tbl.index(attrs["numbers"])
+		rule := tbl.rules[i]
+		tbl.rules = append(tbl.rules[:i], tbl.rules[i+1:]...)
+		tbl.insert(rule, attrs["destination"])
+		r.Done(nil)
+	case "/remove":
+		i := tbl.index(attrs[".id"])
+		tbl.rules = append(tbl.rules[:i], tbl.rules[i+1:]...)
+		r.Done(nil)
+	default:
+		r.Done(nil)
+	}
+}
+
+func (tbl *fakeRuleTable) Comments() []string {
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+
+	comments := make([]string, len(tbl.rules))
+	for i, r := range tbl.rules {
+		comments[i] = r["comment"]
+	}
+
+	return comments
+}
+
+func testFilterRule(chain, comment string) map[string]string {
+	return map[string]string{"chain": chain, "action": "accept", "comment": comment, "disabled": "false", "log": "false"}
+}
+
+func TestCreateResourceBefore(t *testing.T) {
+	tbl := newFakeRuleTable("/ip/firewall/filter", testFilterRule("input", "a"), testFilterRule("input", "c"))
+	f := newFakeRouterOS(t, tbl.handle)
+	c := getFakeClient(t, f)
+
+	id, err := c.CreateResourceBefore(
+		&ResourceFirewallFilter{Chain: "input", Action: "accept", Comment: "b"},
+		&ResourceFirewallFilter{ID: "*2"},
+	)
+	if err != nil {
+		t.Fatalf("expected rule created, got error: %v", err)
+	}
+	if id != "*3" {
+		t.Errorf("expected id *3, got %v", id)
+	}
+
+	if comments := tbl.Comments(); !reflect.DeepEqual(comments, []string{"a", "b", "c"}) {
+		t.Errorf("expected rule placed before c, got %v", comments)
+	}
+
+	if err, _ := c.MoveResource(&ResourceFirewallFilter{ID: "*1"}, nil); err != nil {
+		t.Fatalf("expected rule moved, got error: %v", err)
+	}
+
+	if comments := tbl.Comments(); !reflect.DeepEqual(comments, []string{"b", "c", "a"}) {
+		t.Errorf("expected rule moved to the end, got %v", comments)
+	}
+
+	if _, err := c.CreateResourceBefore(&ResourceDNSStaticRecord{Name: "x", Address: "10.0.0.1"}, nil); err == nil {
+		t.Errorf("expected error for unordered resource, got nil")
+	}
+}
+
+func TestReconcileResources(t *testing.T) {
+	dynamic := testFilterRule("input", "dynamic")
+	dynamic["dynamic"] = "true"
+
+	tbl := newFakeRuleTable("/ip/firewall/filter",
+		testFilterRule("input", "c"),
+		dynamic,
+		testFilterRule("forward", "other chain"),
+		testFilterRule("input", "stale"),
+		testFilterRule("input", "a"),
+	)
+	f := newFakeRouterOS(t, tbl.handle)
+	c := getFakeClient(t, f)
+
+	var rules []Resource
+	for _, comment := range []string{"a", "b", "c"} {
+		rules = append(rules, &ResourceFirewallFilter{Chain: "input", Action: "accept", Comment: comment})
+	}
+
+	ids, err := c.ReconcileResources(rules)
+	if err != nil {
+		t.Fatalf("expected rules reconciled, got error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"*5", "*6", "*1"}) {
+		t.Errorf("expected ids of rules, got %v", ids)
+	}
+
+	expected := []string{"dynamic", "other chain", "a", "b", "c"}
+	if comments := tbl.Comments(); !reflect.DeepEqual(comments, expected) {
+		t.Errorf("expected %v, got %v", expected, comments)
+	}
+
+	// reconciling again changes nothing
+	n := len(f.Commands())
+
+	if _, err := c.ReconcileResources(rules); err != nil {
+		t.Fatalf("expected rules reconciled, got error: %v", err)
+	}
+
+	if cmds := f.Commands()[n:]; !reflect.DeepEqual(cmds, []string{"/ip/firewall/filter/print"}) {
+		t.Errorf("expected only print, got %v", cmds)
+	}
+}
+
+func TestReconcileResourcesNAT(t *testing.T) {
+	tbl := newFakeRuleTable("/ip/firewall/nat",
+		map[string]string{"chain": "dstnat", "action": "dst-nat", "comment": "web", "protocol": "tcp",
+			"dst-port": "80", "to-addresses": "192.168.88.10", "disabled": "false", "log": "false"},
+		map[string]string{"chain": "srcnat", "action": "masquerade", "comment": "masq", "out-interface": "ether1",
+			"disabled": "false", "log": "false"},
+	)
+	f := newFakeRouterOS(t, tbl.handle)
+	c := getFakeClient(t, f)
+
+	rules := []Resource{
+		&ResourceFirewallNAT{Chain: "srcnat", Action: "masquerade", Comment: "masq", OutInterface: "ether1"},
+		&ResourceFirewallNAT{Chain: "dstnat", Action: "dst-nat", Comment: "web", Protocol: "tcp",
+			DstPort: "80", ToAddresses: "192.168.88.10"},
+	}
+
+	ids, err := c.ReconcileResources(rules)
+	if err != nil {
+		t.Fatalf("expected rules reconciled, got error: %v", err)
+	}
+
+	if !reflect.DeepEqual(ids, []string{"*2", "*1"}) {
+		t.Errorf("expected existing rules kept, got %v", ids)
+	}
+
+	if comments := tbl.Comments(); !reflect.DeepEqual(comments, []string{"masq", "web"}) {
+		t.Errorf("expected rules reordered, got %v", comments)
+	}
+
+	mixed := append(rules, &ResourceFirewallFilter{Chain: "input"})
+	if _, err := c.ReconcileResources(mixed); err == nil {
+		t.Errorf("expected error for rules of different menus, got nil")
+	}
+}
+
+func TestCreateResourceBeforeMangle(t *testing.T) {
+	tbl := newFakeRuleTable("/ip/firewall/mangle", map[string]string{"chain": "prerouting", "action": "accept", "comment": "last"})
+	f := newFakeRouterOS(t, tbl.handle)
+	c := getFakeClient(t, f)
+
+	rule := &ResourceFirewallMangle{Chain: "prerouting", Action: "mark-routing", NewRoutingMark: "vpn", Comment: "first"}
+	if _, err := c.CreateResourceBefore(rule, &ResourceFirewallMangle{ID: "*1"}); err != nil {
+		t.Fatalf("expected rule created, got error: %v", err)
+	}
+
+	if comments := tbl.Comments(); !reflect.DeepEqual(comments, []string{"first", "last"}) {
+		t.Errorf("expected rule placed first, got %v", comments)
+	}
+
+	if cmds := f.Commands(); cmds[len(cmds)-1] != "/ip/firewall/mangle/add" {
+		t.Errorf("expected rule added to mangle, got %v", cmds)
+	}
+}
+
+func TestCreateMangleRulePassthrough(t *testing.T) {
+	tbl := newFakeRuleTable("/ip/firewall/mangle")
+	f := newFakeRouterOS(t, tbl.handle)
+	c := getFakeClient(t, f)
+
+	rules := []*ResourceFirewallMangle{
+		{Chain: "prerouting", Action: "mark-routing", NewRoutingMark: "vpn"},
+		{Chain: "prerouting", Action: "mark-routing", NewRoutingMark: "vpn", Passthrough: Bool(false)},
+	}
+
+	for _, rule := range rules {
+		if _, err := c.CreateResourceBefore(rule, nil); err != nil {
+			t.Fatalf("expected rule created, got error: %v", err)
+		}
+	}
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+
+	if v, ok := tbl.rules[0]["passthrough"]; ok {
+		t.Errorf("expected passthrough unset by default, got %v", v)
+	}
+	if v := tbl.rules[1]["passthrough"]; v != "false" {
+		t.Errorf("expected passthrough=false, got %q", v)
+	}
+}
+
+func TestReconcileResourcesDefaults(t *testing.T) {
+	tbl := newFakeRuleTable("/ip/firewall/filter", testFilterRule("input", "extra"))
+	tbl.defaults = map[string]string{"action": "accept", "disabled": "false", "log": "false"}
+	tbl.rules[0]["src-address"] = "10.0.0.1"
+	f := newFakeRouterOS(t, tbl.handle)
+	c := getFakeClient(t, f)
+
+	rules := []Resource{
+		&ResourceFirewallFilter{Chain: "input", Comment: "default"},
+	}
+
+	ids, err := c.ReconcileResources(rules)
+	if err != nil {
+		t.Fatalf("expected rules reconciled, got error: %v", err)
+	}
+
+	if comments := tbl.Comments(); !reflect.DeepEqual(comments, []string{"default"}) {
+		t.Errorf("expected rule with extra matcher replaced, got %v", comments)
+	}
+
+	// reconciling again changes nothing, though RouterOS reports action=accept
+	n := len(f.Commands())
+
+	again, err := c.ReconcileResources(rules)
+	if err != nil {
+		t.Fatalf("expected rules reconciled, got error: %v", err)
+	}
+
+	if !reflect.DeepEqual(again, ids) {
+		t.Errorf("expected rule kept as %v, got %v", ids, again)
+	}
+
+	if cmds := f.Commands()[n:]; !reflect.DeepEqual(cmds, []string{"/ip/firewall/filter/print"}) {
+		t.Errorf("expected only print, got %v", cmds)
+	}
+}
@@ -0,0 +1,271 @@
+package routerosclient
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Reference describes a dependency of a resource on another resource, which
+// is looked up in Menu by the value of its Key attribute.
+// Menu "/interface" is special: it matches any resource under "/interface/",
+// since RouterOS lists interfaces of all kinds (bridges, vlans, ...) there.
+// Strict references must be resolved before the resource is created.
+type Reference struct {
+	Menu   string
+	Key    string
+	Value  string
+	Strict bool
+}
+
+// referrer is implemented by resources which refer to other resources by name,
+// e.g. DHCP server refers to the interface it is running on.
+type referrer interface {
+	getReferences() []Reference
+}
+
+func newReferences(menu, key string, values ...string) []Reference {
+	var refs []Reference
+
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			refs = append(refs, Reference{Menu: menu, Key: key, Value: v})
+		}
+	}
+
+	return refs
+}
+
+// newStrictReferences returns references checked by CreateResource.
+func newStrictReferences(menu, key string, values ...string) []Reference {
+	refs := newReferences(menu, key, values...)
+
+	for i := range refs {
+		refs[i].Strict = true
+	}
+
+	return refs
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("%v[%v=%v]", r.Menu, r.Key, r.Value)
+}
+
+// resolvedBy reports whether res is the resource the reference points to.
+func (r Reference) resolvedBy(res Resource) bool {
+	menu := getResourceMenu(res)
+
+	if r.Menu == "/interface" {
+		if !strings.HasPrefix(menu, "/interface/") {
+			return false
+		}
+	} else if menu != r.Menu {
+		return false
+	}
+
+	attrs, err := buildAttrsFromResource(res)
+	if err != nil {
+		return false
+	}
+
+	return attrs[r.Key] == r.Value
+}
+
+func getReferences(res Resource) []Reference {
+	if r, ok := res.(referrer); ok {
+		return r.getReferences()
+	}
+
+	return nil
+}
+
+// SortResources returns resources ordered so that every resource comes after
+// the resources it refers to. Relative order of independent resources is kept.
+// References to resources outside of the given set are ignored.
+func SortResources(res []Resource) ([]Resource, error) {
+	deps := make([][]int, len(res))
+	done := make([]bool, len(res))
+	sorted := make([]Resource, 0, len(res))
+
+	for i, r := range res {
+		for _, ref := range getReferences(r) {
+			for j, d := range res {
+				if i != j && ref.resolvedBy(d) {
+					deps[i] = append(deps[i], j)
+				}
+			}
+		}
+	}
+
+	for len(sorted) < len(res) {
+		progress := false
+
+		for i, r := range res {
+			if done[i] {
+				continue
+			}
+
+			ready := true
+			for _, j := range deps[i] {
+				if !done[j] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				done[i] = true
+				sorted = append(sorted, r)
+				progress = true
+				// restart to keep the original order as much as possible
+				break
+			}
+		}
+
+		if !progress {
+			var cycle []string
+			for i, r := range res {
+				if !done[i] {
+					cycle = append(cycle, fmt.Sprintf("%v", r))
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between resources: %v", strings.Join(cycle, ", "))
+		}
+	}
+
+	return sorted, nil
+}
+
+// CheckReferences makes sure that every reference of the given resources points
+// either to a resource of the same set or to a resource which exists on RouterOS.
+func (c *Client) CheckReferences(res []Resource) error {
+	var dangling []string
+
+	for _, r := range res {
+		for _, ref := range getReferences(r) {
+			resolved := false
+			for _, d := range res {
+				if d != r && ref.resolvedBy(d) {
+					resolved = true
+					break
+				}
+			}
+
+			if resolved {
+				continue
+			}
+
+			err, ok := c.checkReferenceExists(ref)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				dangling = append(dangling, fmt.Sprintf("%v -> %v", r, ref))
+			}
+		}
+	}
+
+	if len(dangling) > 0 {
+		return fmt.Errorf("dangling references: %v", strings.Join(dangling, "; "))
+	}
+
+	return nil
+}
+
+// checkStrictReferences makes sure resources the strict references
+// of res point to exist.
+func (c *Client) checkStrictReferences(res Resource) error {
+	for _, ref := range getReferences(res) {
+		if !ref.Strict {
+			continue
+		}
+
+		err, ok := c.checkReferenceExists(ref)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("dangling reference: %v -> %v", res, ref)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) checkReferenceExists(ref Reference) (error, bool) {
+	proplist := []string{".id"}
+	attrs := map[string]string{ref.Key: ref.Value}
+
+	cmd, err := buildCommand(ref.Menu+"/print", &proplist, &attrs, true)
+	if err != nil {
+		return err, false
+	}
+	log.Printf("[D][&][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][&][<-] error: %v", err)
+		return err, false
+	}
+	log.Printf("[D][&][<-] %v | %v", r.Re, r.Done)
+
+	return nil, len(r.Re) > 0
+}
+
+// CreateResources creates resources in dependency order after checking that
+// there are no dangling references. Returns ids in the order of given resources.
+func (c *Client) CreateResources(res []Resource) ([]string, error) {
+	log.Printf("[D][C] CreateResources(%v)", res)
+
+	for _, r := range res {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	sorted, err := SortResources(res)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.CheckReferences(res); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[Resource]string, len(res))
+	for _, r := range sorted {
+		id, err := c.CreateResource(r)
+		if err != nil {
+			return nil, err
+		}
+		ids[r] = id
+	}
+
+	ret := make([]string, len(res))
+	for i, r := range res {
+		ret[i] = ids[r]
+	}
+
+	return ret, nil
+}
+
+// DeleteResources deletes resources in reverse dependency order,
+// so that no resource is deleted while something still refers to it.
+func (c *Client) DeleteResources(res []Resource) (error, bool) {
+	log.Printf("[D][D] DeleteResources(%v)", res)
+
+	sorted, err := SortResources(res)
+	if err != nil {
+		return err, false
+	}
+
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if err, ok := c.DeleteResource(sorted[i]); !ok {
+			return err, false
+		}
+	}
+
+	return nil, true
+}
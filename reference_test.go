@@ -0,0 +1,99 @@
+package routerosclient
+
+import (
+	"testing"
+
+	"github.com/go-routeros/routeros"
+)
+
+func TestSortResources(t *testing.T) {
+	lease := &ResourceDHCPServerLease{
+		Address:    "169.254.169.254",
+		MacAddress: "00:11:22:33:44:55",
+		Server:     "test-dhcp-server",
+		DHCPOption: "next-server",
+	}
+	server := &ResourceDHCPServer{
+		Interface: "test-bridge",
+		Name:      "test-dhcp-server",
+	}
+	option := &ResourceDHCPServerOption{
+		Code:  66,
+		Name:  "next-server",
+		Value: "'169.254.169.1'",
+	}
+	bridge := &ResourceInterfaceBridge{
+		Name: "test-bridge",
+	}
+	record := &ResourceDNSStaticRecord{
+		Address: "169.254.169.254",
+		Name:    "host.example.tld",
+	}
+
+	sorted, err := SortResources([]Resource{lease, record, server, option, bridge})
+	if err != nil {
+		t.Fatalf("expected resources sorted, got error: %v", err)
+	}
+
+	expected := []Resource{record, option, bridge, server, lease}
+	for i := range expected {
+		if sorted[i] != expected[i] {
+			t.Errorf("position %v: expected %v, got %v", i, expected[i], sorted[i])
+		}
+	}
+}
+
+func TestCheckReferences(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 2)}
+	s := &scenario{conn: conn}
+	c := &Client{conn: conn}
+
+	server := &ResourceDHCPServer{
+		Interface: "ether1",
+		Name:      "dhcp1",
+	}
+	lease := &ResourceDHCPServerLease{
+		Address:    "169.254.169.254",
+		MacAddress: "00:11:22:33:44:55",
+		Server:     "dhcp1",
+	}
+
+	t.Run("when referenced resource exists", func(t *testing.T) {
+		s.ResourceExists()
+
+		if err := c.CheckReferences([]Resource{server, lease}); err != nil {
+			t.Errorf("expected no error, got: %v", err)
+		}
+	})
+
+	t.Run("when referenced resource does not exist", func(t *testing.T) {
+		s.ResourceDoesNotExist()
+
+		if err := c.CheckReferences([]Resource{server, lease}); err == nil {
+			t.Errorf("expected dangling reference error, got nil")
+		}
+	})
+}
+
+func TestCreateResourceStrictReference(t *testing.T) {
+	conn := &ConnStub{q: make(chan *routeros.Reply, 4)}
+	s := &scenario{conn: conn}
+	c := &Client{conn: conn}
+
+	port := &ResourceInterfaceBridgePort{
+		Bridge:    "br0",
+		Interface: "ether2",
+	}
+
+	// port does not exist, bridge does not exist either
+	s.ResourceDoesNotExist()
+	s.ResourceDoesNotExist()
+
+	if _, err := c.CreateResource(port); err == nil {
+		t.Errorf("expected dangling reference error, got nil")
+	}
+
+	if len(conn.q) != 0 {
+		t.Errorf("expected all replies consumed, %v left", len(conn.q))
+	}
+}
@@ -3,18 +3,70 @@ package routerosclient
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/go-routeros/routeros"
 )
 
+//go:generate go run ./internal/resourcegen -spec resources.yaml
+
 type Resource interface {
 	validate() error
 	getID() string
 	setID(string)
+	getKeys() []string // attributes identifying resource on RouterOS
 	getCreateCommand() string
 	getReadCommand() string
 	getUpdateCommand() string
 	getDeleteCommand() string
 }
 
+// newResource returns a new empty resource of the same type as res.
+func newResource(res Resource) (Resource, error) {
+	t := reflect.TypeOf(res)
+
+	for _, rt := range resourceTypes {
+		if reflect.TypeOf(rt) == t {
+			return reflect.New(t.Elem()).Interface().(Resource), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to determine resource type")
+}
+
+// newResourceByMenu returns a new empty resource living in the given menu,
+// e.g. "/ip/dhcp-server/lease".
+func newResourceByMenu(menu string) (Resource, bool) {
+	for _, rt := range resourceTypes {
+		if getResourceMenu(rt) == menu {
+			nr, _ := newResource(rt)
+			return nr, true
+		}
+	}
+
+	return nil, false
+}
+
+// getResourceKind returns kind of the resource, which is the name of
+// its type without `Resource` prefix, e.g. "DHCPServerLease".
+func getResourceKind(res Resource) string {
+	return strings.TrimPrefix(reflect.TypeOf(res).Elem().Name(), "Resource")
+}
+
+// newResourceByKind returns a new empty resource of the given kind.
+func newResourceByKind(kind string) (Resource, bool) {
+	for _, rt := range resourceTypes {
+		if getResourceKind(rt) == kind {
+			nr, _ := newResource(rt)
+			return nr, true
+		}
+	}
+
+	return nil, false
+}
+
 // CreateResource FIXME: add description
 func (c *Client) CreateResource(res Resource) (string, error) {
 	log.Printf("[D][C] CreateResource(%v)", res)
@@ -29,12 +81,26 @@ func (c *Client) CreateResource(res Resource) (string, error) {
 		return "", err
 	}
 
+	return c.createResource(res, nil)
+}
+
+// createResource adds the resource without checking whether it exists.
+// Extra attributes (e.g. place-before) are added to the command.
+func (c *Client) createResource(res Resource, extra map[string]string) (string, error) {
+	if err := c.checkStrictReferences(res); err != nil {
+		return "", err
+	}
+
 	command := res.getCreateCommand()
-	attrs, err := buildAttrsFromResource(res)
+	attrs, err := c.buildAttrs(res)
 	if err != nil {
 		return "", err
 	}
 
+	for k, v := range extra {
+		attrs[k] = v
+	}
+
 	cmd, err := buildCommand(command, nil, &attrs, false)
 	if err != nil {
 		return "", err
@@ -76,7 +142,7 @@ func (c *Client) UpdateResource(o Resource, n Resource) (error, bool) {
 	n.setID(cur.getID())
 
 	command := o.getUpdateCommand()
-	attrs, err := buildAttrsFromResource(n)
+	attrs, err := c.buildAttrs(n)
 	if err != nil {
 		return err, false
 	}
@@ -95,6 +161,49 @@ func (c *Client) UpdateResource(o Resource, n Resource) (error, bool) {
 	return nil, true
 }
 
+// unsetAttrs unsets attributes which are set in o, but not in n. Both must
+// be the same resource, n carrying its id.
+func (c *Client) unsetAttrs(o Resource, n Resource) error {
+	oattrs, err := c.buildAttrs(o)
+	if err != nil {
+		return err
+	}
+
+	nattrs, err := c.buildAttrs(n)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for name, v := range oattrs {
+		if name != ".id" && v != "" && nattrs[name] == "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	command := getResourceMenu(n) + "/unset"
+
+	for _, name := range names {
+		attrs := map[string]string{"numbers": n.getID(), "value-name": name}
+
+		cmd, err := buildCommand(command, nil, &attrs, false)
+		if err != nil {
+			return err
+		}
+		log.Printf("[D][U][->] %v", cmd)
+
+		r, err := c.Run(cmd)
+		if err != nil {
+			log.Printf("[E][U][<-] error: %v", err)
+			return err
+		}
+		log.Printf("[D][U][<-] %v | %v", r.Re, r.Done)
+	}
+
+	return nil
+}
+
 func (c *Client) ReadResource(res Resource) (Resource, error) {
 	log.Printf("[D][R] ReadResource(%v)", res)
 
@@ -111,7 +220,7 @@ func (c *Client) ReadResource(res Resource) (Resource, error) {
 	if res.getID() != "" {
 		attrs = map[string]string{".id": res.getID()}
 	} else {
-		attrs, err = buildAttrsFromResource(res)
+		attrs, err = c.buildAttrs(res)
 		if err != nil {
 			return nil, err
 		}
@@ -134,25 +243,9 @@ func (c *Client) ReadResource(res Resource) (Resource, error) {
 	case 0:
 		return nil, fmt.Errorf("no resource has been found: %v", res)
 	case 1:
-		var nr Resource
-
-		switch res.(type) {
-		case *ResourceInterfaceBridge:
-			nr = &ResourceInterfaceBridge{}
-		case *ResourceDHCPServer:
-			nr = &ResourceDHCPServer{}
-		case *ResourceDHCPServerNetwork:
-			nr = &ResourceDHCPServerNetwork{}
-		case *ResourceDHCPServerOption:
-			nr = &ResourceDHCPServerOption{}
-		case *ResourceDHCPServerOptionSet:
-			nr = &ResourceDHCPServerOptionSet{}
-		case *ResourceDHCPServerLease:
-			nr = &ResourceDHCPServerLease{}
-		case *ResourceDNSStaticRecord:
-			nr = &ResourceDNSStaticRecord{}
-		default:
-			return nil, fmt.Errorf("unable to determine resource type")
+		nr, err := newResource(res)
+		if err != nil {
+			return nil, err
 		}
 
 		obj, err := setFieldsFromMap(nr, r.Re[0].Map)
@@ -180,8 +273,12 @@ func (c *Client) DeleteResource(res Resource) (error, bool) {
 		return err, false
 	}
 
+	return c.deleteResource(res, resource.getID())
+}
+
+func (c *Client) deleteResource(res Resource, id string) (error, bool) {
 	command := res.getDeleteCommand()
-	attrs := map[string]string{".id": resource.getID()}
+	attrs := map[string]string{".id": id}
 
 	cmd, err := buildCommand(command, nil, &attrs, false)
 	if err != nil {
@@ -209,7 +306,7 @@ func (c *Client) CheckResourceExists(res Resource) (error, bool) {
 	command := res.getReadCommand()
 	proplist := []string{".id"}
 
-	attrs, err := buildAttrsFromResource(res)
+	attrs, err := c.buildAttrs(res)
 	if err != nil {
 		return err, false
 	}
@@ -236,3 +333,110 @@ func (c *Client) CheckResourceExists(res Resource) (error, bool) {
 		return fmt.Errorf("ambiguous reply: %v", r), false
 	}
 }
+
+// ListResources returns all resources of the same type as res.
+func (c *Client) ListResources(res Resource) ([]Resource, error) {
+	log.Printf("[D][L] ListResources(%v)", res)
+
+	r, err := c.printResources(res)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Resource, 0, len(r.Re))
+	for _, re := range r.Re {
+		nr, err := newResource(res)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := setFieldsFromMap(nr, re.Map)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, obj)
+	}
+
+	return list, nil
+}
+
+// EnsureResource makes sure the resource exists on RouterOS. It's looked up
+// by its keys, created if missing and updated if any of its set attributes
+// differs. Returns id of the resource and whether it has been changed.
+func (c *Client) EnsureResource(res Resource) (string, bool, error) {
+	log.Printf("[D][E] EnsureResource(%v)", res)
+
+	if err := res.validate(); err != nil {
+		return "", false, err
+	}
+
+	list, err := c.ListResources(res)
+	if err != nil {
+		return "", false, err
+	}
+
+	key := c.getResourceKey(res)
+
+	var cur Resource
+	for _, r := range list {
+		if !matchesKey(r, key) {
+			continue
+		}
+
+		if cur != nil {
+			return "", false, fmt.Errorf("ambiguous resource: %v", res)
+		}
+		cur = r
+	}
+
+	if cur == nil {
+		id, err := c.CreateResource(res)
+		if err != nil {
+			return "", false, err
+		}
+
+		return id, true, nil
+	}
+
+	if len(diffResources(res, cur)) == 0 {
+		return cur.getID(), false, nil
+	}
+
+	n := cloneResource(res)
+	n.setID(cur.getID())
+
+	attrs, err := c.buildAttrs(n)
+	if err != nil {
+		return "", false, err
+	}
+
+	cmd, err := buildCommand(n.getUpdateCommand(), nil, &attrs, false)
+	if err != nil {
+		return "", false, err
+	}
+	log.Printf("[D][E][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][E][<-] error: %v", err)
+		return "", false, err
+	}
+	log.Printf("[D][E][<-] %v | %v", r.Re, r.Done)
+
+	return cur.getID(), true, nil
+}
+
+func (c *Client) printResources(res Resource) (*routeros.Reply, error) {
+	cmd := res.getReadCommand()
+	log.Printf("[D][L][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][L][<-] error: %v", err)
+		return nil, err
+	}
+	log.Printf("[D][L][<-] %v | %v", r.Re, r.Done)
+
+	return r, nil
+}
@@ -2,8 +2,10 @@ package routerosclient
 
 import (
 	"fmt"
+	"net/netip"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type testResource struct {
@@ -14,10 +16,8 @@ type testResource struct {
 
 func (tr *testResource) setup(c *Client, stub bool) (error, bool) {
 	if !stub {
-		for _, r := range tr.env {
-			if _, err := c.CreateResource(r); err != nil {
-				return err, false
-			}
+		if _, err := c.CreateResources(tr.env); err != nil {
+			return err, false
 		}
 	}
 
@@ -26,12 +26,9 @@ func (tr *testResource) setup(c *Client, stub bool) (error, bool) {
 
 func (tr *testResource) teardown(c *Client, stub bool) (error, bool) {
 	if !stub {
-		// since resources are dependent from each other,
-		// delete them in reverse order
-		for i := len(tr.env) - 1; i >= 0; i-- {
-			if err, ok := c.DeleteResource(tr.env[i]); !ok {
-				return err, false
-			}
+		// resources are deleted in reverse dependency order
+		if err, ok := c.DeleteResources(tr.env); !ok {
+			return err, false
 		}
 	}
 
@@ -40,18 +37,185 @@ func (tr *testResource) teardown(c *Client, stub bool) (error, bool) {
 
 var (
 	resources = []*testResource{
+		&testResource{
+			env: []Resource{
+				&ResourceInterfaceBridge{
+					Name: "br0",
+				},
+			},
+			min: &ResourceInterfaceBridgePort{
+				Bridge:    "br0",
+				Interface: "ether2",
+			},
+			full: &ResourceInterfaceBridgePort{
+				Bridge:           "br0",
+				Comment:          "trunk",
+				Edge:             "no",
+				FrameTypes:       BridgeAdmitOnlyVLANTagged,
+				Horizon:          "none",
+				IngressFiltering: Bool(true),
+				Interface:        "ether2",
+				PointToPoint:     "auto",
+				PVID:             1,
+			},
+		},
+		&testResource{
+			env: []Resource{
+				&ResourceInterfaceBridge{
+					Name:          "br0",
+					VLANFiltering: Bool(true),
+				},
+			},
+			min: &ResourceInterfaceBridgeVLAN{
+				Bridge:  "br0",
+				VLANIDs: []string{"10"},
+			},
+			full: &ResourceInterfaceBridgeVLAN{
+				Bridge:   "br0",
+				Comment:  "office",
+				Tagged:   []string{"br0", "ether2"},
+				Untagged: []string{"ether3"},
+				VLANIDs:  []string{"10", "20-29"},
+			},
+		},
+		&testResource{
+			env: []Resource{
+				&ResourceInterfaceBridge{
+					Name: "br0",
+				},
+			},
+			min: &ResourceInterfaceVLAN{
+				Interface: "br0",
+				Name:      "vlan10",
+				VLANID:    10,
+			},
+			full: &ResourceInterfaceVLAN{
+				ARP:       ARPEnabled,
+				Comment:   "office",
+				Disabled:  true,
+				Interface: "br0",
+				MTU:       1500,
+				Name:      "vlan10",
+				VLANID:    10,
+			},
+		},
+		&testResource{
+			env: []Resource{
+				&ResourceInterfaceBridge{
+					Name: "br0",
+				},
+			},
+			min: &ResourceIPAddress{
+				Address:   netip.MustParsePrefix("192.168.88.1/24"),
+				Interface: "br0",
+			},
+			full: &ResourceIPAddress{
+				Address:   netip.MustParsePrefix("192.168.88.1/24"),
+				Comment:   "lan",
+				Disabled:  true,
+				Interface: "br0",
+				Network:   netip.MustParseAddr("192.168.88.0"),
+			},
+		},
+		&testResource{
+			min: &ResourceIPRoute{
+				DstAddress: netip.MustParsePrefix("10.0.0.0/8"),
+				Gateway:    "169.254.169.1",
+			},
+			full: &ResourceIPRoute{
+				CheckGateway: "ping",
+				Comment:      "vpn",
+				Distance:     10,
+				DstAddress:   netip.MustParsePrefix("10.0.0.0/8"),
+				Gateway:      "169.254.169.1",
+				RoutingTable: "main",
+			},
+		},
+		&testResource{
+			min: &ResourceFirewallFilter{
+				Action: "accept",
+				Chain:  "input",
+			},
+			full: &ResourceFirewallFilter{
+				Action:          "accept",
+				Chain:           "input",
+				Comment:         "ssh",
+				ConnectionState: []string{"new"},
+				DstPort:         "22",
+				InInterface:     "ether1",
+				Log:             true,
+				LogPrefix:       "ssh",
+				Protocol:        "tcp",
+				SrcAddress:      "10.0.0.0/8",
+			},
+		},
+		&testResource{
+			min: &ResourceFirewallNAT{
+				Action:       "masquerade",
+				Chain:        "srcnat",
+				OutInterface: "ether1",
+			},
+			full: &ResourceFirewallNAT{
+				Action:      "dst-nat",
+				Chain:       "dstnat",
+				Comment:     "web",
+				DstPort:     "8080",
+				InInterface: "ether1",
+				Protocol:    "tcp",
+				ToAddresses: "192.168.88.10",
+				ToPorts:     "80",
+			},
+		},
+		&testResource{
+			min: &ResourceFirewallMangle{
+				Action:         "mark-routing",
+				Chain:          "prerouting",
+				NewRoutingMark: "vpn",
+			},
+			full: &ResourceFirewallMangle{
+				Action:            "mark-connection",
+				Chain:             "prerouting",
+				Comment:           "vpn",
+				ConnectionState:   []string{"new"},
+				DstAddressList:    "via-vpn",
+				InInterface:       "ether2",
+				NewConnectionMark: "vpn",
+				Passthrough:       Bool(true),
+			},
+		},
+		&testResource{
+			min: &ResourceFirewallRaw{
+				Action: "drop",
+				Chain:  "prerouting",
+			},
+			full: &ResourceFirewallRaw{
+				Action:         "notrack",
+				Chain:          "prerouting",
+				Comment:        "dns",
+				DstPort:        "53",
+				InInterface:    "ether1",
+				Protocol:       "udp",
+				SrcAddressList: "resolvers",
+			},
+		},
 		&testResource{
 			min: &ResourceInterfaceBridge{
 				Disabled: true,
 				Name:     "br0",
 			},
 			full: &ResourceInterfaceBridge{
-				Comment:      "default bridge",
-				Disabled:     false,
-				FastForward:  true,
-				ForwardDelay: "30s",
-				MTU:          1500,
-				Name:         "br0",
+				AgeingTime:    5 * time.Minute,
+				ARP:           ARPEnabled,
+				ARPTimeout:    "auto",
+				Comment:       "default bridge",
+				Disabled:      false,
+				FastForward:   Bool(true),
+				ForwardDelay:  30 * time.Second,
+				MaxMessageAge: 20 * time.Second,
+				MTU:           "1500",
+				Name:          "br0",
+				Priority:      Int(0x8000),
+				ProtocolMode:  BridgeProtocolRSTP,
 			},
 		},
 		&testResource{
@@ -72,7 +236,11 @@ var (
 				&ResourceInterfaceBridge{
 					Name:     "test-bridge",
 					Disabled: false,
-					MTU:      1500,
+					MTU:      "1500",
+				},
+				&ResourceIPPool{
+					Name:   "test-pool",
+					Ranges: []string{"192.168.0.10-192.168.0.254"},
 				},
 			},
 			min: &ResourceDHCPServer{
@@ -80,9 +248,26 @@ var (
 				Name:      "dhcp1",
 			},
 			full: &ResourceDHCPServer{
-				Interface: "test-bridge",
-				Disabled:  false,
-				Name:      "dhcp1",
+				AddARP:        Bool(true),
+				AddressPool:   "test-pool",
+				Authoritative: DHCPAuthoritativeAfter2Delay,
+				BootpSupport:  DHCPBootpStatic,
+				Interface:     "test-bridge",
+				Disabled:      false,
+				LeaseTime:     10 * time.Minute,
+				Name:          "dhcp1",
+			},
+		},
+		&testResource{
+			min: &ResourceIPPool{
+				Name:   "test-pool",
+				Ranges: []string{"192.168.0.10-192.168.0.254"},
+			},
+			full: &ResourceIPPool{
+				Comment:  "lan",
+				Name:     "test-pool",
+				NextPool: "none",
+				Ranges:   []string{"192.168.0.10-192.168.0.99", "192.168.0.200", "192.168.1.0/24"},
 			},
 		},
 		&testResource{
@@ -141,7 +326,7 @@ var (
 				&ResourceInterfaceBridge{
 					Name:     "test-bridge",
 					Disabled: false,
-					MTU:      1500,
+					MTU:      "1500",
 				},
 				&ResourceDHCPServer{
 					Interface: "test-bridge",
@@ -200,6 +385,7 @@ func TestCreateResource(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			if connIsStub {
 				s.ResourceDoesNotExist()
+				s.ReferencesExist(resource)
 				s.ResourceCreated()
 			}
 
@@ -233,6 +419,7 @@ func TestCreateResource(t *testing.T) {
 		// setup resource: create resource before creating
 		if connIsStub {
 			s.ResourceDoesNotExist()
+			s.ReferencesExist(resource)
 			s.ResourceCreated()
 		}
 		if _, err := c.CreateResource(resource); err != nil {
@@ -312,6 +499,7 @@ func TestReadResource(t *testing.T) {
 		// setup resource: create resource before reading
 		if connIsStub {
 			s.ResourceDoesNotExist()
+			s.ReferencesExist(resource)
 			s.ResourceCreated()
 		}
 		if _, err := c.CreateResource(resource); err != nil {
@@ -393,6 +581,7 @@ func TestUpdateResource(t *testing.T) {
 		// setup resource: create resource before updating
 		if connIsStub {
 			s.ResourceDoesNotExist()
+			s.ReferencesExist(o)
 			s.ResourceCreated()
 		}
 		if _, err := c.CreateResource(o); err != nil {
@@ -468,6 +657,7 @@ func TestDeleteResource(t *testing.T) {
 		// setup resource: create resource before deleting
 		if connIsStub {
 			s.ResourceDoesNotExist()
+			s.ReferencesExist(resource)
 			s.ResourceCreated()
 		}
 		if _, err := c.CreateResource(resource); err != nil {
@@ -539,6 +729,7 @@ func TestCheckResourceExists(t *testing.T) {
 		// setup resource: create resource before checking
 		if connIsStub {
 			s.ResourceDoesNotExist()
+			s.ReferencesExist(resource)
 			s.ResourceCreated()
 		}
 		if _, err := c.CreateResource(resource); err != nil {
@@ -574,3 +765,27 @@ func TestCheckResourceExists(t *testing.T) {
 		}
 	}
 }
+
+func TestReadResourceAuto(t *testing.T) {
+	f := newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		if words[0] == "/interface/bridge/print" {
+			r.Re(map[string]string{".id": "*1", "name": "br0", "mtu": "auto", "disabled": "false"})
+		}
+		r.Done(nil)
+	})
+	c := getFakeClient(t, f)
+
+	res, err := c.ReadResource(&ResourceInterfaceBridge{Name: "br0"})
+	if err != nil {
+		t.Fatalf("expected resource read, got error: %v", err)
+	}
+
+	if b := res.(*ResourceInterfaceBridge); b.Name != "br0" || b.MTU != "auto" {
+		t.Errorf("expected bridge br0 with auto mtu, got %+v", b)
+	}
+
+	list, err := c.ListResources(&ResourceInterfaceBridge{})
+	if err != nil || len(list) != 1 {
+		t.Errorf("expected one bridge listed, got %v, %v", list, err)
+	}
+}
@@ -0,0 +1,24 @@
+// Code generated by resourcegen from resources.yaml; DO NOT EDIT.
+
+package routerosclient
+
+// resourceTypes lists all resource types known to the library.
+var resourceTypes = []Resource{
+	&ResourceInterfaceBridge{},
+	&ResourceInterfaceBridgePort{},
+	&ResourceInterfaceBridgeVLAN{},
+	&ResourceInterfaceVLAN{},
+	&ResourceIPAddress{},
+	&ResourceIPRoute{},
+	&ResourceFirewallFilter{},
+	&ResourceFirewallNAT{},
+	&ResourceFirewallMangle{},
+	&ResourceFirewallRaw{},
+	&ResourceDHCPServer{},
+	&ResourceIPPool{},
+	&ResourceDHCPServerNetwork{},
+	&ResourceDHCPServerOption{},
+	&ResourceDHCPServerOptionSet{},
+	&ResourceDHCPServerLease{},
+	&ResourceDNSStaticRecord{},
+}
@@ -0,0 +1,245 @@
+package routerosclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+)
+
+// RESTConn is a Conn talking to REST API of RouterOS 7 over HTTPS.
+// API sentences are mapped to `POST /rest/<command>` requests:
+//
+//	/ip/address/print =.proplist=.id ?=interface=br0
+//
+// becomes
+//
+//	POST /rest/ip/address/print {".proplist": [".id"], ".query": ["interface=br0"]}
+//
+// so all resource types work unchanged over HTTPS.
+type RESTConn struct {
+	url         string
+	username    string
+	password    string
+	address     string
+	credentials CredentialProvider
+	client      *http.Client
+}
+
+// NewRESTConn returns a connection to REST API at baseURL (e.g. "https://192.168.88.1").
+// If client is nil, http.DefaultClient is used.
+func NewRESTConn(baseURL, username, password string, client *http.Client) *RESTConn {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &RESTConn{
+		url:      strings.TrimSuffix(baseURL, "/") + "/rest",
+		username: username,
+		password: password,
+		client:   client,
+	}
+}
+
+// NewRESTClient returns a client using REST API of RouterOS 7. It's used by
+// NewClient when Config.REST is set.
+func NewRESTClient(c *Config) (*Client, error) {
+	return c.newClient(c.connectREST)
+}
+
+// connectREST returns a REST connection. Since every request is
+// authenticated, credentials are obtained from the provider per request.
+func (c *Config) connectREST() (Conn, error) {
+	// keep defaults like proxy from environment and timeouts
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.TLSConfig != nil && c.TLSConfig.conf != nil {
+		transport.TLSClientConfig = c.TLSConfig.conf.Clone()
+	}
+
+	if c.Dialer != nil {
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if d, ok := c.Dialer.(interface {
+				DialContext(context.Context, string, string) (net.Conn, error)
+			}); ok {
+				return d.DialContext(ctx, network, addr)
+			}
+
+			return c.Dialer.Dial(network, addr)
+		}
+	}
+
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: transport,
+	}
+
+	conn := NewRESTConn("https://"+c.Address, "", "", client)
+	conn.address = c.Address
+	conn.credentials = c.credentials()
+
+	return conn, nil
+}
+
+// RunArgs implements Conn.
+func (c *RESTConn) RunArgs(words []string) (*routeros.Reply, error) {
+	if len(words) == 0 || !strings.HasPrefix(words[0], "/") {
+		return nil, fmt.Errorf("invalid command: %v", words)
+	}
+
+	body := make(map[string]interface{})
+	query := []string{}
+
+	for _, w := range words[1:] {
+		switch {
+		case strings.HasPrefix(w, "=.proplist="):
+			body[".proplist"] = strings.Split(strings.TrimPrefix(w, "=.proplist="), ",")
+		case strings.HasPrefix(w, "="):
+			kv := strings.SplitN(w[1:], "=", 2)
+			if len(kv) == 1 {
+				kv = append(kv, "")
+			}
+			body[kv[0]] = kv[1]
+		case strings.HasPrefix(w, "?="):
+			query = append(query, w[2:])
+		case strings.HasPrefix(w, "?"):
+			query = append(query, w[1:])
+		case strings.HasPrefix(w, ".tag="):
+		default:
+			return nil, fmt.Errorf("invalid word: %v", w)
+		}
+	}
+
+	if len(query) > 0 {
+		body[".query"] = query
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.url+words[0], bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	username, password := c.username, c.password
+	if c.credentials != nil {
+		if username, password, err = c.credentials.Credentials(c.address); err != nil {
+			return nil, fmt.Errorf("unable to get credentials: %v", err)
+		}
+	}
+
+	req.SetBasicAuth(username, password)
+	req.Header.Set("Content-Type", "application/json")
+
+	log.Printf("[D][H][->] POST %v %s", req.URL.Path, b)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[D][H][<-] %v %s", resp.Status, b)
+
+	if resp.StatusCode >= 400 {
+		return nil, restError(resp, b)
+	}
+
+	return restReply(b)
+}
+
+// Close implements Conn.
+func (c *RESTConn) Close() {
+	c.client.CloseIdleConnections()
+}
+
+// restError converts error reply to the same error as API returns.
+func restError(resp *http.Response, b []byte) error {
+	var e struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+
+	message := resp.Status
+	if err := json.Unmarshal(b, &e); err == nil {
+		switch {
+		case e.Detail != "":
+			message = e.Detail
+		case e.Message != "":
+			message = e.Message
+		}
+	}
+
+	return &routeros.DeviceError{
+		Sentence: &proto.Sentence{
+			Word: "!trap",
+			Map:  map[string]string{"message": message},
+		},
+	}
+}
+
+// restReply converts JSON reply to API reply: arrays of objects become
+// `!re` sentences, single object becomes attributes of `!done` sentence.
+func restReply(b []byte) (*routeros.Reply, error) {
+	r := &routeros.Reply{
+		Done: &proto.Sentence{Word: "!done", Map: make(map[string]string)},
+	}
+
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return r, nil
+	}
+
+	if b[0] == '[' {
+		var list []map[string]interface{}
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, err
+		}
+
+		for _, item := range list {
+			r.Re = append(r.Re, restSentence("!re", item))
+		}
+
+		return r, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	r.Done = restSentence("!done", obj)
+
+	return r, nil
+}
+
+func restSentence(word string, obj map[string]interface{}) *proto.Sentence {
+	sen := &proto.Sentence{Word: word, Map: make(map[string]string, len(obj))}
+
+	for k, v := range obj {
+		s, ok := v.(string)
+		if !ok {
+			s = fmt.Sprintf("%v", v)
+		}
+
+		sen.List = append(sen.List, proto.Pair{Key: k, Value: s})
+		sen.Map[k] = s
+	}
+
+	return sen
+}
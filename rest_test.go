@@ -0,0 +1,239 @@
+package routerosclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeREST is an in-memory RouterOS REST API supporting add/print/set/remove.
+type fakeREST struct {
+	mu     sync.Mutex
+	nextID int
+	menus  map[string][]map[string]string
+}
+
+func (f *fakeREST) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if u, p, ok := r.BasicAuth(); !ok || u != "admin" || p != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error":401,"message":"Unauthorized"}`)
+		return
+	}
+
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/rest/") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	menu, cmd := path.Split(strings.TrimPrefix(r.URL.Path, "/rest"))
+	menu = strings.TrimSuffix(menu, "/")
+	items := f.menus[menu]
+
+	find := func(id interface{}) int {
+		for i, item := range items {
+			if item[".id"] == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	reply := func(v interface{}) {
+		json.NewEncoder(w).Encode(v)
+	}
+
+	trap := func(detail string) {
+		w.WriteHeader(http.StatusBadRequest)
+		reply(map[string]interface{}{"error": 400, "message": "Bad Request", "detail": detail})
+	}
+
+	switch cmd {
+	case "add":
+		f.nextID++
+		item := map[string]string{".id": fmt.Sprintf("*%X", f.nextID)}
+		for k, v := range body {
+			item[k] = v.(string)
+		}
+		f.menus[menu] = append(items, item)
+		reply(map[string]string{"ret": item[".id"]})
+	case "print":
+		list := []map[string]string{}
+	items:
+		for _, item := range items {
+			if q, ok := body[".query"]; ok {
+				for _, cond := range q.([]interface{}) {
+					kv := strings.SplitN(cond.(string), "=", 2)
+					if item[kv[0]] != kv[1] {
+						continue items
+					}
+				}
+			}
+
+			if p, ok := body[".proplist"]; ok {
+				props := map[string]string{}
+				for _, name := range p.([]interface{}) {
+					props[name.(string)] = item[name.(string)]
+				}
+				item = props
+			}
+
+			list = append(list, item)
+		}
+		reply(list)
+	case "set":
+		i := find(body[".id"])
+		if i < 0 {
+			trap("no such item")
+			return
+		}
+		for k, v := range body {
+			items[i][k] = v.(string)
+		}
+		reply([]interface{}{})
+	case "remove":
+		i := find(body[".id"])
+		if i < 0 {
+			trap("no such item")
+			return
+		}
+		f.menus[menu] = append(items[:i], items[i+1:]...)
+		reply([]interface{}{})
+	default:
+		trap("no such command")
+	}
+}
+
+func getRESTClient(t *testing.T, password string) *Client {
+	fake := &fakeREST{menus: map[string][]map[string]string{
+		"/system/resource": {{"version": "7.12.1 (stable)"}},
+	}}
+
+	srv := httptest.NewTLSServer(fake)
+	t.Cleanup(srv.Close)
+
+	c := newClient(NewRESTConn(srv.URL, "admin", password, srv.Client()))
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+func TestRESTConn(t *testing.T) {
+	c := getRESTClient(t, "secret")
+
+	if v := c.Version(); v != mustParseVersion("7.12.1 (stable)") {
+		t.Errorf("expected version detected, got %v", v)
+	}
+
+	o := &ResourceDNSStaticRecord{Address: "192.168.0.1", Name: "router.lan"}
+	n := &ResourceDNSStaticRecord{Address: "192.168.0.2", Name: "router.lan", TTL: "1h"}
+
+	id, err := c.CreateResource(o)
+	if err != nil {
+		t.Fatalf("expected resource created, got error: %v", err)
+	}
+
+	if _, err := c.CreateResource(o); err == nil {
+		t.Errorf("expected error creating existing resource, got nil")
+	}
+
+	res, err := c.ReadResource(o)
+	if err != nil {
+		t.Fatalf("expected resource read, got error: %v", err)
+	}
+	if res.getID() != id || res.(*ResourceDNSStaticRecord).Address != o.Address {
+		t.Errorf("expected %v with id %v, got %v", o, id, res)
+	}
+
+	if err, ok := c.UpdateResource(o, n); !ok {
+		t.Fatalf("expected resource updated, got error: %v", err)
+	}
+
+	list, err := c.ListResources(n)
+	if err != nil {
+		t.Fatalf("expected resources listed, got error: %v", err)
+	}
+	if len(list) != 1 || list[0].(*ResourceDNSStaticRecord).TTL != "1h" {
+		t.Errorf("expected updated resource, got %v", list)
+	}
+
+	if err, ok := c.DeleteResource(n); !ok {
+		t.Fatalf("expected resource deleted, got error: %v", err)
+	}
+
+	if err, ok := c.CheckResourceExists(n); err != nil || ok {
+		t.Errorf("expected resource not to exist, got %v, %v", err, ok)
+	}
+
+	if _, err := c.Run("/ip/dns/static/remove =.id=*1"); err == nil || !strings.Contains(err.Error(), "no such item") {
+		t.Errorf("expected device error, got %v", err)
+	}
+}
+
+func TestRESTConnUnauthorized(t *testing.T) {
+	c := getRESTClient(t, "wrong")
+
+	if _, err := c.ListResources(&ResourceDNSStaticRecord{}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestRESTClientTLSConfig(t *testing.T) {
+	fake := &fakeREST{menus: map[string][]map[string]string{
+		"/system/resource": {{"version": "7.12.1 (stable)"}},
+	}}
+
+	srv := httptest.NewTLSServer(fake)
+	t.Cleanup(srv.Close)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	tests := []struct {
+		name    string
+		conf    *TLSConfig
+		trusted bool
+	}{
+		{"with server certificate trusted", NewTLSConfig(&tls.Config{RootCAs: pool}), true},
+		{"with default config", NewTLSConfig(nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(&Config{
+				Address:   strings.TrimPrefix(srv.URL, "https://"),
+				Username:  "admin",
+				Password:  "secret",
+				REST:      true,
+				TLSConfig: tt.conf,
+			})
+			if err != nil {
+				t.Fatalf("expected client, got error: %v", err)
+			}
+			defer c.Close()
+
+			if transport := c.conn.(*RESTConn).client.Transport.(*http.Transport); transport.Proxy == nil {
+				t.Errorf("expected proxy from environment, got none")
+			}
+
+			if _, err := c.Run("/system/resource/print"); (err == nil) != tt.trusted {
+				t.Errorf("expected trusted %v, got error: %v", tt.trusted, err)
+			}
+		})
+	}
+}
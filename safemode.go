@@ -0,0 +1,114 @@
+package routerosclient
+
+import (
+	"fmt"
+	"log"
+)
+
+// RouterOS reverts all changes made in safe mode if the session which
+// entered it terminates abnormally, so a broken bridge or interface setup
+// can't lock us out of the router.
+const (
+	safeModeEnterCommand   = "/safe-mode/enter"
+	safeModeCommitCommand  = "/safe-mode/commit"
+	safeModeReleaseCommand = "/safe-mode/release"
+)
+
+// EnterSafeMode enters safe mode. Changes made after that are reverted
+// by RouterOS unless CommitSafeMode is called before the session ends.
+func (c *Client) EnterSafeMode() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.safeMode {
+		return fmt.Errorf("safe mode has been entered already")
+	}
+
+	if err := c.runSafeModeCommand(safeModeEnterCommand); err != nil {
+		return err
+	}
+	c.safeMode = true
+
+	return nil
+}
+
+// CommitSafeMode leaves safe mode keeping all changes made in it.
+// If it fails, the session stays in safe mode.
+func (c *Client) CommitSafeMode() error {
+	return c.leaveSafeMode(safeModeCommitCommand)
+}
+
+// ExitSafeMode leaves safe mode reverting all changes made in it.
+func (c *Client) ExitSafeMode() error {
+	return c.leaveSafeMode(safeModeReleaseCommand)
+}
+
+func (c *Client) leaveSafeMode(cmd string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.safeMode {
+		return fmt.Errorf("safe mode has not been entered")
+	}
+
+	if err := c.runSafeModeCommand(cmd); err != nil {
+		return err
+	}
+	c.safeMode = false
+
+	return nil
+}
+
+// InSafeMode reports whether the client is in safe mode.
+func (c *Client) InSafeMode() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.safeMode
+}
+
+// WithSafeMode runs fn in safe mode. Changes are committed if fn succeeds
+// and released (reverted) if it returns an error, panics or commit fails.
+func (c *Client) WithSafeMode(fn func() error) (err error) {
+	if err := c.EnterSafeMode(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if rerr := c.ExitSafeMode(); rerr != nil {
+				log.Printf("[E][S] unable to release safe mode: %v", rerr)
+			}
+			panic(p)
+		}
+
+		if err == nil {
+			if err = c.CommitSafeMode(); err == nil {
+				return
+			}
+			// changes can't be kept, release them rather than leaving
+			// the session in safe mode
+		}
+
+		if rerr := c.ExitSafeMode(); rerr != nil {
+			err = fmt.Errorf("%w; unable to release safe mode: %v", err, rerr)
+		}
+	}()
+
+	return fn()
+}
+
+// runSafeModeCommand runs the command with c.mu held, so safe mode state
+// changes along with the session.
+func (c *Client) runSafeModeCommand(cmd string) error {
+	log.Printf("[D][S][->] %v", cmd)
+
+	r, err := c.conn.RunArgs([]string{cmd})
+	if err != nil {
+		log.Printf("[E][S][<-] error: %v", err)
+		return err
+	}
+	log.Printf("[D][S][<-] %v | %v", r.Re, r.Done)
+
+	return nil
+}
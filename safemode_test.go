@@ -0,0 +1,117 @@
+package routerosclient
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func getFakeClient(t *testing.T, f *FakeRouterOS) *Client {
+	c, err := NewClient(&Config{
+		Address:  f.Addr(),
+		Username: "admin",
+		Password: "admin",
+	})
+	if err != nil {
+		t.Fatalf("unable to connect to fake RouterOS: %v", err)
+	}
+	t.Cleanup(c.Close)
+
+	return c
+}
+
+func TestWithSafeMode(t *testing.T) {
+	failure := errors.New("provisioning failed")
+
+	tests := []struct {
+		name     string
+		err      error
+		trap     string // command failing on RouterOS
+		inSafe   bool
+		commands []string
+	}{
+		{
+			name:     "when succeeds",
+			commands: []string{safeModeEnterCommand, "/interface/bridge/add", safeModeCommitCommand},
+		},
+		{
+			name:     "when fails",
+			err:      failure,
+			commands: []string{safeModeEnterCommand, "/interface/bridge/add", safeModeReleaseCommand},
+		},
+		{
+			name:     "when commit fails",
+			trap:     safeModeCommitCommand,
+			commands: []string{safeModeEnterCommand, "/interface/bridge/add", safeModeCommitCommand, safeModeReleaseCommand},
+		},
+		{
+			name:     "when release fails",
+			err:      failure,
+			trap:     safeModeReleaseCommand,
+			inSafe:   true,
+			commands: []string{safeModeEnterCommand, "/interface/bridge/add", safeModeReleaseCommand},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeRouterOS(t, func(r *FakeReply, words []string) {
+				if words[0] == tt.trap {
+					r.Trap("failure")
+					return
+				}
+				r.Done(nil)
+			})
+			c := getFakeClient(t, f)
+
+			err := c.WithSafeMode(func() error {
+				if _, err := c.Run("/interface/bridge/add =name=br0"); err != nil {
+					return err
+				}
+				return tt.err
+			})
+
+			if tt.trap != "" && err == nil {
+				t.Errorf("expected error, got nil")
+			}
+			if tt.trap == "" && tt.err == nil && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if c.InSafeMode() != tt.inSafe {
+				t.Errorf("expected in safe mode: %v, got %v", tt.inSafe, c.InSafeMode())
+			}
+			if cmds := f.Commands(); !reflect.DeepEqual(cmds, tt.commands) {
+				t.Errorf("expected commands %v, got %v", tt.commands, cmds)
+			}
+		})
+	}
+}
+
+func TestEnterSafeMode(t *testing.T) {
+	f := newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		if words[0] == safeModeEnterCommand {
+			r.Trap("safe mode is taken by another session")
+			return
+		}
+		r.Done(nil)
+	})
+	c := getFakeClient(t, f)
+
+	if err := c.EnterSafeMode(); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if c.InSafeMode() {
+		t.Errorf("expected not to be in safe mode")
+	}
+
+	called := false
+	if err := c.WithSafeMode(func() error { called = true; return nil }); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if called {
+		t.Errorf("expected fn not to be called when safe mode can't be entered")
+	}
+}
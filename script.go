@@ -0,0 +1,186 @@
+package routerosclient
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ExportScript renders resources as a RouterOS script (.rsc), which could be
+// reviewed and imported with `/import`. Resources are ordered by their
+// dependencies and grouped by menu:
+//
+//	/ip dhcp-server lease
+//	add address=192.168.0.10 mac-address=00:11:22:33:44:55 server=dhcp1
+func ExportScript(w io.Writer, res []Resource) error {
+	sorted, err := SortResources(res)
+	if err != nil {
+		return err
+	}
+
+	menu := ""
+	for _, r := range groupByMenu(sorted) {
+		if err := r.validate(); err != nil {
+			return err
+		}
+
+		if m := getResourceMenu(r); m != menu {
+			menu = m
+			if _, err := fmt.Fprintln(w, formatScriptMenu(menu)); err != nil {
+				return err
+			}
+		}
+
+		command := strings.TrimPrefix(r.getCreateCommand(), menu+"/")
+		if _, err := fmt.Fprintln(w, formatScriptCommand(command, r)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// groupByMenu groups sorted resources by menu, so every menu header is
+// written once. Menus are ordered by their dependencies and then by first
+// appearance, resources of a menu keep their order. If menus depend on each
+// other mutually, resources are left as they are.
+func groupByMenu(sorted []Resource) []Resource {
+	var menus []string
+	byMenu := make(map[string][]Resource)
+	deps := make(map[string]map[string]bool)
+
+	for _, r := range sorted {
+		menu := getResourceMenu(r)
+		if _, ok := byMenu[menu]; !ok {
+			menus = append(menus, menu)
+			deps[menu] = make(map[string]bool)
+		}
+		byMenu[menu] = append(byMenu[menu], r)
+
+		for _, ref := range getReferences(r) {
+			for _, d := range sorted {
+				if dm := getResourceMenu(d); dm != menu && ref.resolvedBy(d) {
+					deps[menu][dm] = true
+				}
+			}
+		}
+	}
+
+	grouped := make([]Resource, 0, len(sorted))
+	done := make(map[string]bool)
+
+	for len(done) < len(menus) {
+		progress := false
+
+		for _, menu := range menus {
+			if done[menu] {
+				continue
+			}
+
+			ready := true
+			for dm := range deps[menu] {
+				if !done[dm] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				done[menu] = true
+				grouped = append(grouped, byMenu[menu]...)
+				progress = true
+				// restart to keep the order of appearance as much as possible
+				break
+			}
+		}
+
+		if !progress {
+			return sorted
+		}
+	}
+
+	return grouped
+}
+
+// formatScriptMenu converts menu path to script syntax:
+// "/ip/dhcp-server/lease" -> "/ip dhcp-server lease".
+func formatScriptMenu(menu string) string {
+	return "/" + strings.Join(strings.Split(strings.Trim(menu, "/"), "/"), " ")
+}
+
+func formatScriptCommand(command string, r Resource) string {
+	words := []string{command}
+
+	for _, f := range getFields(r) {
+		if f.name == ".id" || f.readOnly() {
+			continue
+		}
+
+		v := formatValue(f.value)
+		if v == "" {
+			continue
+		}
+
+		if fv := reflect.Indirect(f.value); fv.Kind() == reflect.Bool {
+			v = formatScriptBool(fv.Bool())
+		}
+
+		words = append(words, f.name+"="+quoteScriptValue(v))
+	}
+
+	return strings.Join(words, " ")
+}
+
+func formatScriptBool(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// quoteScriptValue quotes the value if needed, escaping characters which have
+// special meaning in RouterOS scripting language.
+func quoteScriptValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\r\n\"'\\$?;=[]{}()#") && isPrintable(s) {
+		return s
+	}
+
+	b := &strings.Builder{}
+	b.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\', '$', '?':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(b, `\%02X`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func isPrintable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] >= 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
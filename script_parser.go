@@ -0,0 +1,419 @@
+package routerosclient
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// scriptCommands are commands which could follow a menu path in a script.
+var scriptCommands = map[string]bool{
+	"add":     true,
+	"set":     true,
+	"remove":  true,
+	"unset":   true,
+	"enable":  true,
+	"disable": true,
+	"move":    true,
+	"comment": true,
+}
+
+// ScriptAttr is an attribute of a script statement.
+type ScriptAttr struct {
+	Name  string
+	Value string
+}
+
+// ScriptStatement is a statement of a script in a menu which has no
+// corresponding resource type, or which can't be represented as a resource.
+type ScriptStatement struct {
+	Menu    string            // menu path, e.g. "/ip/firewall/filter"
+	Command string            // e.g. "add" or "set"
+	Find    map[string]string // selector, e.g. `[ find default-name=ether1 ]`
+	Args    []string          // positional arguments, e.g. item numbers
+	Attrs   []ScriptAttr
+}
+
+// ParsedScript is a result of ParseScript.
+type ParsedScript struct {
+	// Resources are created from `add` statements in menus having
+	// a resource type. `set [ find ... ]` statements are merged into
+	// the preceding resource matched by the selector.
+	Resources []Resource
+	// Raw contains all other statements.
+	Raw []ScriptStatement
+}
+
+// ParseScript parses RouterOS script produced by `/export`.
+func ParseScript(r io.Reader) (*ParsedScript, error) {
+	lines, err := readScriptLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedScript{}
+	menu := ""
+
+	for n, line := range lines {
+		tokens, err := tokenizeScript(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n+1, err)
+		}
+
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(tokens[0], "/") {
+			var path []string
+
+			path = append(path, strings.Trim(tokens[0], "/"))
+			tokens = tokens[1:]
+
+			for len(tokens) > 0 && !scriptCommands[tokens[0]] && !strings.ContainsAny(tokens[0], "=[") {
+				path = append(path, tokens[0])
+				tokens = tokens[1:]
+			}
+
+			menu = "/" + strings.Join(path, "/")
+
+			if len(tokens) == 0 {
+				continue
+			}
+		}
+
+		if menu == "" {
+			return nil, fmt.Errorf("line %v: statement outside of menu: %v", n+1, line)
+		}
+
+		st, err := parseScriptStatement(menu, tokens)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n+1, err)
+		}
+
+		merged, err := st.mergeInto(parsed.Resources)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n+1, err)
+		}
+
+		if merged {
+			continue
+		}
+
+		res, err := st.toResource()
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %v", n+1, err)
+		}
+
+		if res != nil {
+			parsed.Resources = append(parsed.Resources, res)
+		} else {
+			parsed.Raw = append(parsed.Raw, *st)
+		}
+	}
+
+	return parsed, nil
+}
+
+// readScriptLines reads script lines joining continued ones
+// and skipping comments.
+func readScriptLines(r io.Reader) ([]string, error) {
+	var lines []string
+	var cur strings.Builder
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+
+		if cur.Len() > 0 {
+			line = strings.TrimLeft(line, " \t")
+		} else if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		if strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`) {
+			cur.WriteString(strings.TrimSuffix(line, `\`))
+			continue
+		}
+
+		cur.WriteString(line)
+		lines = append(lines, cur.String())
+		cur.Reset()
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if cur.Len() > 0 {
+		lines = append(lines, cur.String())
+	}
+
+	return lines, nil
+}
+
+// tokenizeScript splits a line into words. Quoted strings are unquoted,
+// bracketed expressions are returned as a single word including brackets.
+func tokenizeScript(line string) ([]string, error) {
+	var tokens []string
+	var cur strings.Builder
+
+	inToken := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case c == ' ' || c == '\t':
+			if inToken {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				inToken = false
+			}
+		case c == '"':
+			s, n, err := unquoteScriptValue(line[i:])
+			if err != nil {
+				return nil, err
+			}
+			cur.WriteString(s)
+			inToken = true
+			i += n - 1
+		case c == '[':
+			n, err := scanScriptBrackets(line[i:])
+			if err != nil {
+				return nil, err
+			}
+			cur.WriteString(line[i : i+n])
+			inToken = true
+			i += n - 1
+		default:
+			cur.WriteByte(c)
+			inToken = true
+		}
+	}
+
+	if inToken {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens, nil
+}
+
+// unquoteScriptValue unquotes a string at the beginning of s.
+// Returns the string and the number of bytes consumed.
+func unquoteScriptValue(s string) (string, int, error) {
+	var b strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+
+		switch c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, fmt.Errorf("unterminated escape sequence")
+			}
+			i++
+
+			switch e := s[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'a':
+				b.WriteByte('\a')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'v':
+				b.WriteByte('\v')
+			case '_':
+				b.WriteByte(' ')
+			default:
+				if i+1 < len(s) && isHexDigit(e) && isHexDigit(s[i+1]) {
+					v, _ := strconv.ParseUint(s[i:i+2], 16, 8)
+					b.WriteByte(byte(v))
+					i++
+				} else {
+					b.WriteByte(e)
+				}
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+// scanScriptBrackets returns length of the bracketed expression at the beginning of s.
+func scanScriptBrackets(s string) (int, error) {
+	depth := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			_, n, err := unquoteScriptValue(s[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n - 1
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("unterminated bracket expression")
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'F') || (c >= 'a' && c <= 'f')
+}
+
+func parseScriptStatement(menu string, tokens []string) (*ScriptStatement, error) {
+	st := &ScriptStatement{
+		Menu:    menu,
+		Command: tokens[0],
+	}
+
+	for _, t := range tokens[1:] {
+		switch {
+		case strings.HasPrefix(t, "["):
+			find, err := parseScriptFind(t)
+			if err != nil {
+				return nil, err
+			}
+			st.Find = find
+		case strings.Contains(t, "="):
+			kv := strings.SplitN(t, "=", 2)
+			st.Attrs = append(st.Attrs, ScriptAttr{Name: kv[0], Value: kv[1]})
+		default:
+			st.Args = append(st.Args, t)
+		}
+	}
+
+	return st, nil
+}
+
+// parseScriptFind parses selector like `[ find where name=br0 ]`.
+func parseScriptFind(expr string) (map[string]string, error) {
+	tokens, err := tokenizeScript(strings.TrimSuffix(strings.TrimPrefix(expr, "["), "]"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 || tokens[0] != "find" {
+		return nil, fmt.Errorf("unsupported expression: %v", expr)
+	}
+
+	find := make(map[string]string)
+	for _, t := range tokens[1:] {
+		if t == "where" {
+			continue
+		}
+
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("unsupported expression: %v", expr)
+		}
+		find[kv[0]] = kv[1]
+	}
+
+	return find, nil
+}
+
+// toResource converts statement to a resource, if its menu has a resource
+// type. Returns nil if it has not or if the statement can't be represented
+// as a resource.
+func (st *ScriptStatement) toResource() (Resource, error) {
+	if len(st.Args) > 0 || st.Command != "add" || st.Find != nil {
+		return nil, nil
+	}
+
+	res, ok := newResourceByMenu(st.Menu)
+	if !ok {
+		return nil, nil
+	}
+
+	return setFieldsFromMap(res, st.attrs(res))
+}
+
+// mergeInto applies `set [ find ... ]` statement to the last of resources
+// in its menu matched by the selector. Returns false if there is no such
+// resource.
+func (st *ScriptStatement) mergeInto(resources []Resource) (bool, error) {
+	if len(st.Args) > 0 || st.Command != "set" || st.Find == nil {
+		return false, nil
+	}
+
+	sel, ok := newResourceByMenu(st.Menu)
+	if !ok {
+		return false, nil
+	}
+
+	if _, err := setFieldsFromMap(sel, st.Find); err != nil {
+		return false, err
+	}
+
+	key := make(map[string]string)
+	for _, f := range getFields(sel) {
+		if _, ok := st.Find[f.name]; ok {
+			key[f.name] = formatValue(f.value)
+		}
+	}
+
+	// selector using unknown attributes can't be matched
+	if len(key) != len(st.Find) {
+		return false, nil
+	}
+
+	for i := len(resources) - 1; i >= 0; i-- {
+		res := resources[i]
+		if getResourceMenu(res) != st.Menu || !matchesKey(res, key) {
+			continue
+		}
+
+		if _, err := setFieldsFromMap(res, st.attrs(res)); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// attrs returns attributes of the statement, warning about ones unknown
+// to the resource.
+func (st *ScriptStatement) attrs(res Resource) map[string]string {
+	attrs := make(map[string]string)
+	for _, a := range st.Attrs {
+		attrs[a.Name] = a.Value
+	}
+
+	known := make(map[string]bool)
+	for _, f := range getFields(res) {
+		known[f.name] = true
+	}
+	for k := range attrs {
+		if !known[k] {
+			log.Printf("[W] attribute `%v` is unknown in %v (ignoring)", k, st.Menu)
+		}
+	}
+
+	return attrs
+}
@@ -0,0 +1,105 @@
+package routerosclient
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testExport = `# oct/19/2026 10:00:00 by RouterOS 6.49.10
+# software id = ABCD-1234
+#
+/interface bridge
+add fast-forward=no mtu=1500 name=br0
+set [ find name=br0 ] comment="default bridge"
+set [ find name=br1 ] mtu=1400
+/interface ethernet
+set [ find default-name=ether1 ] comment=uplink
+/ip dhcp-server
+add disabled=no interface=br0 name=dhcp1
+/ip dhcp-server lease
+add address=192.168.0.10 comment="printer \"2nd floor\"" mac-address=\
+    00:11:22:33:44:55 server=dhcp1
+/ip firewall filter
+add action=accept chain=input comment="allow \$var" protocol=icmp
+/ip dns static add address=192.168.0.1 name=router.lan
+`
+
+func TestParseScript(t *testing.T) {
+	parsed, err := ParseScript(strings.NewReader(testExport))
+	if err != nil {
+		t.Fatalf("expected script parsed, got error: %v", err)
+	}
+
+	expected := []Resource{
+		&ResourceInterfaceBridge{Comment: "default bridge", FastForward: Bool(false), MTU: "1500", Name: "br0"},
+		&ResourceDHCPServer{Interface: "br0", Name: "dhcp1"},
+		&ResourceDHCPServerLease{
+			Address:    "192.168.0.10",
+			Comment:    `printer "2nd floor"`,
+			MacAddress: "00:11:22:33:44:55",
+			Server:     "dhcp1",
+		},
+		&ResourceFirewallFilter{Action: "accept", Chain: "input", Comment: "allow $var", Protocol: "icmp"},
+		&ResourceDNSStaticRecord{Address: "192.168.0.1", Name: "router.lan"},
+	}
+
+	if !reflect.DeepEqual(parsed.Resources, expected) {
+		t.Errorf("expected resources %v, got %v", expected, parsed.Resources)
+	}
+
+	expectedRaw := []ScriptStatement{
+		{
+			Menu:    "/interface/bridge",
+			Command: "set",
+			Find:    map[string]string{"name": "br1"},
+			Attrs:   []ScriptAttr{{Name: "mtu", Value: "1400"}},
+		},
+		{
+			Menu:    "/interface/ethernet",
+			Command: "set",
+			Find:    map[string]string{"default-name": "ether1"},
+			Attrs:   []ScriptAttr{{Name: "comment", Value: "uplink"}},
+		},
+	}
+
+	if !reflect.DeepEqual(parsed.Raw, expectedRaw) {
+		t.Errorf("expected raw statements %+v, got %+v", expectedRaw, parsed.Raw)
+	}
+}
+
+func TestParseScriptRoundTrip(t *testing.T) {
+	res := []Resource{
+		&ResourceInterfaceBridge{Comment: "a \"quoted\" $tring\twith\x01specials", Name: "br0"},
+		&ResourceDHCPServerOption{Code: 66, Name: "next-server", Value: "'192.168.0.2'"},
+	}
+
+	b := &bytes.Buffer{}
+	if err := ExportScript(b, res); err != nil {
+		t.Fatalf("expected script, got error: %v", err)
+	}
+
+	parsed, err := ParseScript(b)
+	if err != nil {
+		t.Fatalf("expected script parsed, got error: %v", err)
+	}
+
+	if !reflect.DeepEqual(parsed.Resources, res) {
+		t.Errorf("expected resources %v, got %v", res, parsed.Resources)
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	tests := []string{
+		`add name=br0`,
+		"/interface bridge\nadd comment=\"unterminated name=br0",
+		"/interface bridge\nset [ find name=br0 comment=x",
+	}
+
+	for _, script := range tests {
+		if _, err := ParseScript(strings.NewReader(script)); err == nil {
+			t.Errorf("expected error parsing %q, got nil", script)
+		}
+	}
+}
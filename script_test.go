@@ -0,0 +1,106 @@
+package routerosclient
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExportScript(t *testing.T) {
+	res := []Resource{
+		&ResourceDHCPServerLease{
+			Address:    "192.168.0.10",
+			Comment:    `printer "2nd floor"`,
+			MacAddress: "00:11:22:33:44:55",
+			Server:     "dhcp1",
+		},
+		&ResourceDHCPServer{
+			Interface: "br0",
+			Name:      "dhcp1",
+		},
+		&ResourceInterfaceBridge{
+			FastForward: Bool(false),
+			MTU:         "1500",
+			Name:        "br0",
+		},
+		&ResourceDHCPServerOption{
+			Code:  66,
+			Name:  "next-server",
+			Value: "'192.168.0.2'",
+		},
+	}
+
+	expected := `/interface bridge
+add disabled=no fast-forward=no mtu=1500 name=br0
+/ip dhcp-server
+add disabled=no name=dhcp1 interface=br0
+/ip dhcp-server lease
+add address=192.168.0.10 comment="printer \"2nd floor\"" disabled=no mac-address=00:11:22:33:44:55 server=dhcp1
+/ip dhcp-server option
+add code=66 name=next-server value="'192.168.0.2'"
+`
+
+	b := &bytes.Buffer{}
+	if err := ExportScript(b, res); err != nil {
+		t.Fatalf("expected script, got error: %v", err)
+	}
+
+	if b.String() != expected {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, b.String())
+	}
+}
+
+func TestExportScriptGroupsMenus(t *testing.T) {
+	res := []Resource{
+		&ResourceInterfaceBridge{
+			FastForward: Bool(false),
+			MTU:         "1500",
+			Name:        "br0",
+		},
+		&ResourceDHCPServer{
+			Interface: "br0",
+			Name:      "dhcp1",
+		},
+		&ResourceInterfaceBridge{
+			FastForward: Bool(false),
+			MTU:         "1500",
+			Name:        "br1",
+		},
+	}
+
+	expected := `/interface bridge
+add disabled=no fast-forward=no mtu=1500 name=br0
+add disabled=no fast-forward=no mtu=1500 name=br1
+/ip dhcp-server
+add disabled=no name=dhcp1 interface=br0
+`
+
+	b := &bytes.Buffer{}
+	if err := ExportScript(b, res); err != nil {
+		t.Fatalf("expected script, got error: %v", err)
+	}
+
+	if b.String() != expected {
+		t.Errorf("expected:\n%v\ngot:\n%v", expected, b.String())
+	}
+}
+
+func TestQuoteScriptValue(t *testing.T) {
+	tests := map[string]string{
+		"br0":            "br0",
+		"":               `""`,
+		"two words":      `"two words"`,
+		`$var`:           `"\$var"`,
+		`back\slash`:     `"back\\slash"`,
+		"line\nbreak":    `"line\nbreak"`,
+		"a=b":            `"a=b"`,
+		"\x01":           `"\01"`,
+		"'192.168.0.2'":  `"'192.168.0.2'"`,
+		"192.168.0.0/24": "192.168.0.0/24",
+	}
+
+	for in, expected := range tests {
+		if out := quoteScriptValue(in); out != expected {
+			t.Errorf("%q: expected %v, got %v", in, expected, out)
+		}
+	}
+}
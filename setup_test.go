@@ -41,6 +41,15 @@ func (s *scenario) ResourceDoesNotExist() {
 	s.conn.buildReply(nil, nil)
 }
 
+// ReferencesExist replies to checks of strict references of res.
+func (s *scenario) ReferencesExist(res Resource) {
+	for _, ref := range getReferences(res) {
+		if ref.Strict {
+			s.ResourceExists()
+		}
+	}
+}
+
 func (s *scenario) ResourceUpdated() {
 	s.conn.buildReply(nil, nil)
 }
@@ -89,7 +98,7 @@ func getTestTimeClient() (*Client, error) {
 	case "stub":
 		c = &Client{
 			conn: &ConnStub{
-				q: make(chan *routeros.Reply, 2),
+				q: make(chan *routeros.Reply, 4),
 			},
 		}
 	}
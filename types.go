@@ -0,0 +1,63 @@
+package routerosclient
+
+// ARPMode is a value of `arp` attribute of interfaces.
+type ARPMode string
+
+const (
+	ARPEnabled       ARPMode = "enabled"
+	ARPDisabled      ARPMode = "disabled"
+	ARPProxyARP      ARPMode = "proxy-arp"
+	ARPReplyOnly     ARPMode = "reply-only"
+	ARPLocalProxyARP ARPMode = "local-proxy-arp"
+)
+
+// BridgeProtocolMode is a spanning tree protocol used by a bridge.
+type BridgeProtocolMode string
+
+const (
+	BridgeProtocolNone BridgeProtocolMode = "none"
+	BridgeProtocolSTP  BridgeProtocolMode = "stp"
+	BridgeProtocolRSTP BridgeProtocolMode = "rstp"
+	BridgeProtocolMSTP BridgeProtocolMode = "mstp"
+)
+
+// BridgeFrameTypes specifies frame types allowed on a bridge port.
+type BridgeFrameTypes string
+
+const (
+	BridgeAdmitAll            BridgeFrameTypes = "admit-all"
+	BridgeAdmitOnlyUntagged   BridgeFrameTypes = "admit-only-untagged-and-priority-tagged"
+	BridgeAdmitOnlyVLANTagged BridgeFrameTypes = "admit-only-vlan-tagged"
+)
+
+// DHCPAuthoritative specifies how a DHCP server responds to clients
+// requesting addresses it doesn't know about.
+type DHCPAuthoritative string
+
+const (
+	DHCPAuthoritativeYes          DHCPAuthoritative = "yes"
+	DHCPAuthoritativeNo           DHCPAuthoritative = "no"
+	DHCPAuthoritativeAfter2Delay  DHCPAuthoritative = "after-2sec-delay"
+	DHCPAuthoritativeAfter10Delay DHCPAuthoritative = "after-10sec-delay"
+)
+
+// DHCPBootpSupport specifies how a DHCP server responds to BOOTP requests.
+type DHCPBootpSupport string
+
+const (
+	DHCPBootpNone    DHCPBootpSupport = "none"
+	DHCPBootpStatic  DHCPBootpSupport = "static"
+	DHCPBootpDynamic DHCPBootpSupport = "dynamic"
+)
+
+// Bool returns a pointer to the value, for optional boolean attributes
+// which are unset when nil.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int returns a pointer to the value, for optional integer attributes
+// which are unset when nil.
+func Int(v int) *int {
+	return &v
+}
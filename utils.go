@@ -1,13 +1,30 @@
 package routerosclient
 
 import (
+	"encoding"
 	"fmt"
 	"log"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// durationUnits are units of RouterOS time values, e.g. "1w2d3h4m5s".
+var durationUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+}
+
 func buildCommand(command string, proplist *[]string, attrs *map[string]string, isQuery bool) (string, error) {
 
 	cmd := command
@@ -31,17 +48,61 @@ func buildCommand(command string, proplist *[]string, attrs *map[string]string,
 	return cmd, nil
 }
 
-func buildAttrsFromResource(i interface{}) (map[string]string, error) {
+// field is a struct field carrying `ros` tag.
+// Tag may contain options after attribute name, e.g. `ros:"vlan-filtering,min=6.41"`:
+//   - min, max: range of RouterOS versions supporting the attribute
+//   - readonly: attribute is reported by RouterOS, but never written or queried
+//   - v6: name of the attribute in RouterOS 6, e.g. `ros:"routing-table,v6=routing-mark"`
+//   - default: value RouterOS reports for the attribute when it's unset
+type field struct {
+	name    string // RouterOS attribute name
+	value   reflect.Value
+	options map[string]string
+}
+
+func (f field) readOnly() bool {
+	_, ok := f.options["readonly"]
+	return ok
+}
+
+// getFields returns fields of a resource carrying `ros` tag in order of declaration.
+func getFields(i interface{}) []field {
 	v := reflect.ValueOf(i).Elem()
-	attrs := make(map[string]string)
+	fields := []field{}
 
 	for j := 0; j < v.NumField(); j++ {
 		fieldTag := v.Type().Field(j).Tag.Get("ros")
 
 		if fieldTag != "" {
-			attrs[fieldTag] = fmt.Sprintf("%v", v.Field(j))
+			parts := strings.Split(fieldTag, ",")
+			options := make(map[string]string)
+
+			for _, o := range parts[1:] {
+				kv := strings.SplitN(o, "=", 2)
+				if len(kv) == 1 {
+					kv = append(kv, "")
+				}
+				options[kv[0]] = kv[1]
+			}
+
+			fields = append(fields, field{
+				name:    parts[0],
+				value:   v.Field(j),
+				options: options,
+			})
 		}
+	}
+
+	return fields
+}
 
+func buildAttrsFromResource(i interface{}) (map[string]string, error) {
+	attrs := make(map[string]string)
+
+	for _, f := range getFields(i) {
+		if !f.readOnly() {
+			attrs[f.name] = formatValue(f.value)
+		}
 	}
 
 	return attrs, nil
@@ -49,41 +110,242 @@ func buildAttrsFromResource(i interface{}) (map[string]string, error) {
 
 func setFieldsFromMap(r Resource, m map[string]string) (Resource, error) {
 
-	v := reflect.ValueOf(r).Elem()
+	for _, f := range getFields(r) {
+		fval := f.value
 
-	if v.Kind() == reflect.Struct {
-		for i := 0; i < v.NumField(); i++ {
-			ftag := v.Type().Field(i).Tag.Get("ros")
-			fname := v.Type().Field(i).Name
-			fval := v.Field(i)
-
-			if m[ftag] != "" {
-				if fval.CanSet() && fval.IsValid() {
-					switch fval.Kind() {
-					case reflect.Bool:
-						newVal, err := strconv.ParseBool(m[ftag])
-						if err != nil {
-							return nil, err
-						}
-						fval.SetBool(newVal)
-					case reflect.Int:
-						newVal, err := strconv.ParseInt(m[ftag], 0, 0)
-						if !fval.OverflowInt(newVal) {
-							fval.SetInt(newVal)
-						} else {
-							return nil, err
-						}
-					default:
-						fval.SetString(m[ftag])
-					}
-				} else {
-					log.Printf("[W] field `%v` is not settable (ignoring)", fname)
+		if v6, ok := f.options["v6"]; ok && m[f.name] == "" {
+			f.name = v6
+		}
+
+		if m[f.name] != "" {
+			if fval.CanSet() && fval.IsValid() {
+				if err := setValue(fval, f.name, m[f.name]); err != nil {
+					return nil, err
 				}
 			} else {
-				log.Printf("[W] attribute `%v` has no value (ignoring)", ftag)
+				log.Printf("[W] field `%v` is not settable (ignoring)", f.name)
 			}
+		} else {
+			log.Printf("[W] attribute `%v` has no value (ignoring)", f.name)
 		}
 	}
 
 	return r, nil
 }
+
+// setValue sets field value from RouterOS representation.
+func setValue(fval reflect.Value, name, s string) error {
+	switch fval.Kind() {
+	case reflect.Bool:
+		newVal, err := parseBool(s)
+		if err != nil {
+			return err
+		}
+		fval.SetBool(newVal)
+	case reflect.Int:
+		// e.g. mtu of a bridge is `auto` unless set
+		if s == "auto" {
+			fval.SetInt(0)
+			return nil
+		}
+		newVal, err := strconv.ParseInt(s, 0, 0)
+		if err != nil {
+			return err
+		}
+		if fval.OverflowInt(newVal) {
+			return fmt.Errorf("value of `%v` overflows int: %v", name, newVal)
+		}
+		fval.SetInt(newVal)
+	case reflect.Int64:
+		if fval.Type() != durationType {
+			return fmt.Errorf("unsupported type of `%v`: %v", name, fval.Type())
+		}
+		newVal, err := parseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid value of `%v`: %v", name, err)
+		}
+		fval.SetInt(int64(newVal))
+	case reflect.Ptr:
+		newVal := reflect.New(fval.Type().Elem())
+		if err := setValue(newVal.Elem(), name, s); err != nil {
+			return err
+		}
+		fval.Set(newVal)
+	case reflect.Struct:
+		u, ok := fval.Addr().Interface().(encoding.TextUnmarshaler)
+		if !ok {
+			return fmt.Errorf("unsupported type of `%v`: %v", name, fval.Type())
+		}
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return fmt.Errorf("invalid value of `%v`: %v", name, err)
+		}
+	case reflect.Slice:
+		if fval.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type of `%v`: %v", name, fval.Type())
+		}
+		list := strings.Split(s, ",")
+		newVal := reflect.MakeSlice(fval.Type(), len(list), len(list))
+		for i, e := range list {
+			newVal.Index(i).SetString(e)
+		}
+		fval.Set(newVal)
+	default:
+		fval.SetString(s)
+	}
+
+	return nil
+}
+
+// getResourceMenu returns menu path of the resource, e.g. "/ip/dhcp-server/lease".
+func getResourceMenu(r Resource) string {
+	cmd := r.getCreateCommand()
+
+	return cmd[:strings.LastIndex(cmd, "/")]
+}
+
+// cloneResource returns a shallow copy of the resource.
+func cloneResource(r Resource) Resource {
+	v := reflect.ValueOf(r).Elem()
+	n := reflect.New(v.Type())
+	n.Elem().Set(v)
+
+	return n.Interface().(Resource)
+}
+
+// formatValue returns RouterOS representation of a field value.
+// Zero values of non-boolean fields are considered unset and formatted as "".
+// Pointers allow to set zero values, e.g. `*bool` is unset only when nil.
+func formatValue(v reflect.Value) string {
+	if v.Kind() != reflect.Bool && v.IsZero() {
+		return ""
+	}
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Type() == durationType {
+		return formatDuration(time.Duration(v.Int()))
+	}
+
+	if v.Kind() == reflect.Slice {
+		list := make([]string, v.Len())
+		for i := range list {
+			list[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(list, ",")
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+// getResourceKey returns attributes identifying the resource on RouterOS.
+// Resources without keys are identified by all attributes being set.
+func getResourceKey(r Resource) map[string]string {
+	key := make(map[string]string)
+	keys := r.getKeys()
+
+	for _, f := range getFields(r) {
+		if f.name == ".id" || f.readOnly() {
+			continue
+		}
+
+		if len(keys) == 0 {
+			if v := formatValue(f.value); v != "" {
+				key[f.name] = v
+			}
+			continue
+		}
+
+		for _, k := range keys {
+			if k == f.name {
+				key[f.name] = formatValue(f.value)
+			}
+		}
+	}
+
+	return key
+}
+
+// matchesKey reports whether r is identified by key.
+func matchesKey(r Resource, key map[string]string) bool {
+	attrs := make(map[string]string)
+	for _, f := range getFields(r) {
+		attrs[f.name] = formatValue(f.value)
+	}
+
+	for k, v := range key {
+		if attrs[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseBool parses booleans in both API (true/false) and script (yes/no) notation.
+func parseBool(s string) (bool, error) {
+	switch s {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	}
+
+	return strconv.ParseBool(s)
+}
+
+// parseDuration parses RouterOS time value. Both v7 ("1d2h3m", "30s") and
+// v6 ("1d02:03:00", "00:00:30") notations are accepted, a bare number
+// means seconds.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("invalid time: %v", s)
+	}
+
+	var d time.Duration
+	rest := s
+
+	// weeks and days are not supported by time.ParseDuration
+	for _, u := range durationUnits[:2] {
+		if i := strings.Index(rest, u.suffix); i >= 0 {
+			n, err := strconv.ParseUint(rest[:i], 10, 32)
+			if err != nil {
+				return 0, fmt.Errorf("invalid time: %v", s)
+			}
+			d += time.Duration(n) * u.unit
+			rest = rest[i+1:]
+		}
+	}
+
+	if rest == "" {
+		return d, nil
+	}
+
+	if parts := strings.Split(rest, ":"); len(parts) == 3 {
+		rest = parts[0] + "h" + parts[1] + "m" + parts[2] + "s"
+	} else if _, err := strconv.ParseUint(rest, 10, 32); err == nil {
+		rest += "s"
+	}
+
+	v, err := time.ParseDuration(rest)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid time: %v", s)
+	}
+
+	return d + v, nil
+}
+
+// formatDuration formats RouterOS time value, e.g. "1d2h3m".
+func formatDuration(d time.Duration) string {
+	b := &strings.Builder{}
+
+	for _, u := range durationUnits {
+		if n := d / u.unit; n > 0 {
+			fmt.Fprintf(b, "%d%v", n, u.suffix)
+			d -= n * u.unit
+		}
+	}
+
+	return b.String()
+}
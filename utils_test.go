@@ -0,0 +1,203 @@
+package routerosclient
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"30s":        30 * time.Second,
+		"30":         30 * time.Second,
+		"5m":         5 * time.Minute,
+		"1w2d3h":     9*24*time.Hour + 3*time.Hour,
+		"00:05:00":   5 * time.Minute,
+		"1d02:03:04": 26*time.Hour + 3*time.Minute + 4*time.Second,
+		"100ms":      100 * time.Millisecond,
+	}
+
+	for s, expected := range cases {
+		if d, err := parseDuration(s); err != nil || d != expected {
+			t.Errorf("%v: expected %v, got %v, %v", s, expected, d, err)
+		}
+	}
+
+	for _, s := range []string{"", "auto", "xd", "-5s", "1:2"} {
+		if _, err := parseDuration(s); err == nil {
+			t.Errorf("%v: expected error, got nil", s)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		30 * time.Second:                "30s",
+		5 * time.Minute:                 "5m",
+		9*24*time.Hour + 90*time.Minute: "1w2d1h30m",
+		1500 * time.Millisecond:         "1s500ms",
+	}
+
+	for d, expected := range cases {
+		if s := formatDuration(d); s != expected {
+			t.Errorf("%v: expected %v, got %v", d, expected, s)
+		}
+	}
+}
+
+func TestSetFieldsFromMapDuration(t *testing.T) {
+	res, err := setFieldsFromMap(&ResourceInterfaceBridge{}, map[string]string{
+		"name":        "br0",
+		"ageing-time": "00:05:00",
+		"arp":         "proxy-arp",
+		"priority":    "0x8000",
+	})
+	if err != nil {
+		t.Fatalf("expected fields set, got error: %v", err)
+	}
+
+	br := res.(*ResourceInterfaceBridge)
+	if br.AgeingTime != 5*time.Minute || br.ARP != ARPProxyARP || br.Priority == nil || *br.Priority != 0x8000 {
+		t.Errorf("unexpected resource: %+v", br)
+	}
+
+	attrs, _ := buildAttrsFromResource(br)
+	if attrs["ageing-time"] != "5m" || attrs["priority"] != "32768" || attrs["mtu"] != "" {
+		t.Errorf("unexpected attributes: %v", attrs)
+	}
+}
+
+func TestValidateBridge(t *testing.T) {
+	invalid := []*ResourceInterfaceBridge{
+		{Name: "br0", ARP: "on"},
+		{Name: "br0", ProtocolMode: "pvst"},
+		{Name: "br0", AdminMAC: "00:11:22"},
+		{Name: "br0", ARPTimeout: "never"},
+		{Name: "br0", MaxMessageAge: time.Minute},
+		{Name: "br0", ForwardDelay: time.Second},
+		{Name: "br0", MTU: "jumbo"},
+		{Name: "br0", MTU: "0"},
+		{Name: "br0", Priority: Int(65536)},
+		{Name: "br0", TransmitHoldCount: 11},
+	}
+
+	for _, br := range invalid {
+		if err := br.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", br)
+		}
+	}
+
+	valid := &ResourceInterfaceBridge{
+		Name:          "br0",
+		AdminMAC:      "00:11:22:33:44:55",
+		ARP:           ARPReplyOnly,
+		ARPTimeout:    "30s",
+		ForwardDelay:  15 * time.Second,
+		MaxMessageAge: 20 * time.Second,
+		MTU:           "auto",
+		ProtocolMode:  BridgeProtocolMSTP,
+	}
+
+	if err := valid.validate(); err != nil {
+		t.Errorf("expected valid bridge, got error: %v", err)
+	}
+}
+
+func TestReadOnlyFields(t *testing.T) {
+	res, err := setFieldsFromMap(&ResourceInterfaceVLAN{}, map[string]string{
+		"name":      "vlan10",
+		"interface": "br0",
+		"vlan-id":   "10",
+		"running":   "true",
+	})
+	if err != nil {
+		t.Fatalf("expected fields set, got error: %v", err)
+	}
+
+	vlan := res.(*ResourceInterfaceVLAN)
+	if !vlan.Running {
+		t.Errorf("expected read-only field decoded, got %+v", vlan)
+	}
+
+	attrs, _ := buildAttrsFromResource(vlan)
+	if _, ok := attrs["running"]; ok {
+		t.Errorf("expected read-only attribute omitted, got %v", attrs)
+	}
+
+	if diff := diffResources(vlan, &ResourceInterfaceVLAN{Name: "vlan10", Interface: "br0", VLANID: 10}); len(diff) != 0 {
+		t.Errorf("expected read-only attribute not compared, got %v", diff)
+	}
+
+	for _, v := range []*ResourceInterfaceVLAN{
+		{Name: "vlan0", Interface: "br0"},
+		{Name: "vlan5000", Interface: "br0", VLANID: 5000},
+	} {
+		if err := v.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", v)
+		}
+	}
+}
+
+func TestSetFieldsFromMapTextUnmarshaler(t *testing.T) {
+	res, err := setFieldsFromMap(&ResourceIPAddress{}, map[string]string{
+		".id":       "*1",
+		"address":   "192.168.88.1/24",
+		"network":   "192.168.88.0",
+		"interface": "br0",
+		"dynamic":   "false",
+	})
+	if err != nil {
+		t.Fatalf("expected fields set, got error: %v", err)
+	}
+
+	addr := res.(*ResourceIPAddress)
+	if addr.Address != netip.MustParsePrefix("192.168.88.1/24") || addr.Network != netip.MustParseAddr("192.168.88.0") {
+		t.Errorf("unexpected resource: %+v", addr)
+	}
+
+	if key := getResourceKey(addr); key["address"] != "192.168.88.1/24" || key["interface"] != "br0" {
+		t.Errorf("unexpected key: %v", key)
+	}
+
+	if _, err := setFieldsFromMap(&ResourceIPAddress{}, map[string]string{"address": "192.168.88.1"}); err == nil {
+		t.Errorf("expected error for address without prefix length, got nil")
+	}
+
+	for _, a := range []*ResourceIPAddress{
+		{Interface: "br0"},
+		{Address: netip.MustParsePrefix("2001:db8::1/64"), Interface: "br0"},
+		{Address: netip.MustParsePrefix("192.168.88.1/24")},
+	} {
+		if err := a.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", a)
+		}
+	}
+}
+
+func TestBuildAttrsUnset(t *testing.T) {
+	br := &ResourceInterfaceBridge{Name: "br0"}
+
+	attrs, _ := buildAttrsFromResource(br)
+	for _, name := range []string{"auto-mac", "dhcp-snooping", "igmp-snooping", "vlan-filtering", "priority"} {
+		if v, ok := attrs[name]; ok && v != "" {
+			t.Errorf("expected `%v` unset, got %v", name, v)
+		}
+	}
+
+	br.AutoMAC = Bool(false)
+	br.Priority = Int(0)
+
+	attrs, _ = buildAttrsFromResource(br)
+	if attrs["auto-mac"] != "false" || attrs["priority"] != "0" {
+		t.Errorf("expected zero values set, got %v", attrs)
+	}
+
+	res, err := setFieldsFromMap(&ResourceInterfaceBridge{}, map[string]string{"name": "br0", "auto-mac": "true", "priority": "0"})
+	if err != nil {
+		t.Fatalf("expected fields set, got error: %v", err)
+	}
+
+	if br := res.(*ResourceInterfaceBridge); br.AutoMAC == nil || !*br.AutoMAC || br.Priority == nil || *br.Priority != 0 {
+		t.Errorf("unexpected resource: %+v", br)
+	}
+}
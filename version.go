@@ -0,0 +1,289 @@
+package routerosclient
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var versionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)(?:\.(\d+))?((?:alpha|beta|rc)\d*)?(?:\s+\((\S+)\))?$`)
+
+// Version is a RouterOS version, e.g. "7.12.1 (stable)".
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Pre is a pre-release suffix, e.g. "beta2" or "rc1"
+	Pre string
+	// Channel is a release channel, e.g. "stable" or "long-term"
+	Channel string
+}
+
+// ParseVersion parses version as reported by /system/resource.
+func ParseVersion(s string) (Version, error) {
+	m := versionRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid RouterOS version: %q", s)
+	}
+
+	v := Version{Pre: m[4], Channel: m[5]}
+	v.Major, _ = strconv.Atoi(m[1])
+	v.Minor, _ = strconv.Atoi(m[2])
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+
+	return v, nil
+}
+
+func mustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// IsZero reports whether the version is unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Compare returns -1, 0 or 1 if v is less than, equal to or greater than o.
+// Channel is not taken into account, pre-releases precede releases.
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		} else if d > 0 {
+			return 1
+		}
+	}
+
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	}
+
+	// alpha, beta and rc are ordered alphabetically, their numbers numerically
+	vk, vn := splitPre(v.Pre)
+	ok, on := splitPre(o.Pre)
+
+	switch {
+	case vk < ok:
+		return -1
+	case vk > ok:
+		return 1
+	case vn < on:
+		return -1
+	case vn > on:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// splitPre splits a pre-release suffix into its kind and number,
+// e.g. "beta10" into "beta" and 10.
+func splitPre(pre string) (string, int) {
+	kind := strings.TrimRight(pre, "0123456789")
+	n, _ := strconv.Atoi(pre[len(kind):])
+
+	return kind, n
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	if v.Patch != 0 {
+		s += fmt.Sprintf(".%d", v.Patch)
+	}
+	s += v.Pre
+	if v.Channel != "" {
+		s += fmt.Sprintf(" (%v)", v.Channel)
+	}
+
+	return s
+}
+
+// versioned is implemented by resources which menu exists only in some
+// versions of RouterOS. Empty string means there is no limit.
+type versioned interface {
+	getVersionRange() (min string, max string)
+}
+
+// Version returns version of the connected RouterOS. It's zero, if the version
+// couldn't be detected, and no version checks are performed in that case.
+func (c *Client) Version() Version {
+	return c.version
+}
+
+func (c *Client) detectVersion() error {
+	cmd := "/system/resource/print =.proplist=version"
+	log.Printf("[D][V][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][V][<-] error: %v", err)
+		return err
+	}
+	log.Printf("[D][V][<-] %v | %v", r.Re, r.Done)
+
+	if len(r.Re) != 1 {
+		return fmt.Errorf("unexpected reply to %v", cmd)
+	}
+
+	v, err := ParseVersion(r.Re[0].Map["version"])
+	if err != nil {
+		return err
+	}
+	c.version = v
+
+	return nil
+}
+
+// supports reports whether version lies within [min, max] range.
+// Range bounds are versions like "6.41", empty bound means no limit.
+func supports(v Version, min, max string) (bool, error) {
+	if min != "" {
+		m, err := ParseVersion(min)
+		if err != nil {
+			return false, err
+		}
+		if v.Compare(m) < 0 {
+			return false, nil
+		}
+	}
+
+	if max != "" {
+		m, err := ParseVersion(max)
+		if err != nil {
+			return false, err
+		}
+		if v.Compare(m) > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func formatVersionRange(min, max string) string {
+	switch {
+	case min != "" && max != "":
+		return fmt.Sprintf("RouterOS %v..%v", min, max)
+	case min != "":
+		return fmt.Sprintf("RouterOS >= %v", min)
+	default:
+		return fmt.Sprintf("RouterOS <= %v", max)
+	}
+}
+
+// checkVersion returns error if menu of the resource is not supported
+// by the connected RouterOS.
+func (c *Client) checkVersion(res Resource) error {
+	vr, ok := res.(versioned)
+	if !ok || c.version.IsZero() {
+		return nil
+	}
+
+	min, max := vr.getVersionRange()
+
+	ok, err := supports(c.version, min, max)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("%v requires %v, connected to %v", getResourceMenu(res), formatVersionRange(min, max), c.version)
+	}
+
+	return nil
+}
+
+// buildAttrs builds attributes of the resource supported by the connected
+// RouterOS. Unsupported attributes are omitted if they are not set, and
+// rejected with error otherwise. Attributes renamed in RouterOS 7 are
+// given their old names when connected to RouterOS 6.
+func (c *Client) buildAttrs(res Resource) (map[string]string, error) {
+	if err := c.checkVersion(res); err != nil {
+		return nil, err
+	}
+
+	attrs, err := buildAttrsFromResource(res)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.version.IsZero() {
+		return attrs, nil
+	}
+
+	for _, f := range getFields(res) {
+		min, max := f.options["min"], f.options["max"]
+		if min == "" && max == "" {
+			continue
+		}
+
+		ok, err := supports(c.version, min, max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range of `%v`: %v", f.name, err)
+		}
+
+		if ok {
+			continue
+		}
+
+		if v := formatValue(f.value); v != "" && v != "false" {
+			return nil, fmt.Errorf("attribute `%v` requires %v, connected to %v", f.name, formatVersionRange(min, max), c.version)
+		}
+
+		delete(attrs, f.name)
+	}
+
+	if c.version.Major < 7 {
+		for _, f := range getFields(res) {
+			if v6, ok := f.options["v6"]; ok {
+				if v, ok := attrs[f.name]; ok {
+					delete(attrs, f.name)
+					attrs[v6] = v
+				}
+			}
+		}
+	}
+
+	return attrs, nil
+}
+
+// getResourceKey returns key of the resource as reported by the connected
+// RouterOS: unset key attributes are given their defaults, e.g. routing-table
+// of a route reads back as `main` in RouterOS 7. Defaults don't apply to
+// attributes reported under their RouterOS 6 names.
+func (c *Client) getResourceKey(res Resource) map[string]string {
+	key := getResourceKey(res)
+
+	for _, f := range getFields(res) {
+		if v, ok := key[f.name]; !ok || v != "" {
+			continue
+		}
+
+		if _, ok := f.options["v6"]; ok && c.isV6() {
+			continue
+		}
+
+		key[f.name] = f.options["default"]
+	}
+
+	return key
+}
+
+// isV6 reports whether the client is connected to RouterOS 6.
+func (c *Client) isV6() bool {
+	return !c.version.IsZero() && c.version.Major < 7
+}
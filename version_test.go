@@ -0,0 +1,189 @@
+package routerosclient
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]Version{
+		"7.12.1 (stable)":     {Major: 7, Minor: 12, Patch: 1, Channel: "stable"},
+		"6.49.10 (long-term)": {Major: 6, Minor: 49, Patch: 10, Channel: "long-term"},
+		"7.13beta2 (testing)": {Major: 7, Minor: 13, Pre: "beta2", Channel: "testing"},
+		"7.1rc3":              {Major: 7, Minor: 1, Pre: "rc3"},
+		"6.41":                {Major: 6, Minor: 41},
+	}
+
+	for s, expected := range tests {
+		v, err := ParseVersion(s)
+		if err != nil {
+			t.Errorf("%v: expected version, got error: %v", s, err)
+		}
+		if v != expected {
+			t.Errorf("%v: expected %+v, got %+v", s, expected, v)
+		}
+	}
+
+	for _, s := range []string{"", "7", "v7.1", "7.x"} {
+		if _, err := ParseVersion(s); err == nil {
+			t.Errorf("%q: expected error, got nil", s)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		cmp  int
+	}{
+		{"7.12.1", "7.12.1 (stable)", 0},
+		{"6.49.10", "7.1", -1},
+		{"7.10", "7.9.2", 1},
+		{"7.13beta2", "7.13", -1},
+		{"7.13rc1", "7.13beta2", 1},
+		{"7.13beta10", "7.13beta2", 1},
+		{"7.13beta2", "7.13beta10", -1},
+		{"7.13beta", "7.13beta1", -1},
+		{"7.13alpha10", "7.13beta1", -1},
+	}
+
+	for _, tt := range tests {
+		if cmp := mustParseVersion(tt.a).Compare(mustParseVersion(tt.b)); cmp != tt.cmp {
+			t.Errorf("%v vs %v: expected %v, got %v", tt.a, tt.b, tt.cmp, cmp)
+		}
+	}
+}
+
+// testVersionedResource lives in a menu which appeared in RouterOS 7.
+type testVersionedResource struct {
+	ID      string `ros:".id"`
+	Name    string `ros:"name"`
+	Feature bool   `ros:"feature,min=7.1"`
+	Legacy  string `ros:"legacy,max=6.99"`
+}
+
+func (d *testVersionedResource) validate() error                 { return nil }
+func (d *testVersionedResource) getID() string                   { return d.ID }
+func (d *testVersionedResource) setID(id string)                 { d.ID = id }
+func (*testVersionedResource) getKeys() []string                 { return []string{"name"} }
+func (*testVersionedResource) getVersionRange() (string, string) { return "7.0", "" }
+func (*testVersionedResource) getCreateCommand() string          { return "/test/add" }
+func (*testVersionedResource) getReadCommand() string            { return "/test/print" }
+func (*testVersionedResource) getUpdateCommand() string          { return "/test/set" }
+func (*testVersionedResource) getDeleteCommand() string          { return "/test/remove" }
+
+func TestBuildAttrsVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		res     *testVersionedResource
+		attrs   map[string]string
+	}{
+		{
+			version: "",
+			res:     &testVersionedResource{Name: "x", Legacy: "y"},
+			attrs:   map[string]string{".id": "", "name": "x", "feature": "false", "legacy": "y"},
+		},
+		{
+			version: "7.12.1",
+			res:     &testVersionedResource{Name: "x", Feature: true},
+			attrs:   map[string]string{".id": "", "name": "x", "feature": "true"},
+		},
+		{
+			version: "7.0",
+			res:     &testVersionedResource{Name: "x"},
+			attrs:   map[string]string{".id": "", "name": "x"},
+		},
+		{
+			version: "7.0",
+			res:     &testVersionedResource{Name: "x", Feature: true},
+		},
+		{
+			version: "7.12.1",
+			res:     &testVersionedResource{Name: "x", Legacy: "y"},
+		},
+		{
+			version: "6.49.10",
+			res:     &testVersionedResource{Name: "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		c := &Client{}
+		if tt.version != "" {
+			c.version = mustParseVersion(tt.version)
+		}
+
+		attrs, err := c.buildAttrs(tt.res)
+
+		if tt.attrs == nil {
+			if err == nil {
+				t.Errorf("%v/%+v: expected error, got %v", tt.version, tt.res, attrs)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%v/%+v: expected attrs, got error: %v", tt.version, tt.res, err)
+			continue
+		}
+
+		if len(attrs) != len(tt.attrs) {
+			t.Errorf("%v/%+v: expected %v, got %v", tt.version, tt.res, tt.attrs, attrs)
+		}
+		for k, v := range tt.attrs {
+			if attrs[k] != v {
+				t.Errorf("%v/%+v: expected %v, got %v", tt.version, tt.res, tt.attrs, attrs)
+			}
+		}
+	}
+}
+
+func TestBuildAttrsRenamed(t *testing.T) {
+	route := &ResourceIPRoute{
+		DstAddress:   netip.MustParsePrefix("10.0.0.0/8"),
+		Gateway:      "192.168.88.254",
+		RoutingTable: "vpn",
+	}
+
+	names := map[string][2]string{
+		"6.49.10": {"routing-mark", "routing-table"},
+		"7.12.1":  {"routing-table", "routing-mark"},
+	}
+
+	for version, name := range names {
+		c := &Client{version: mustParseVersion(version)}
+
+		attrs, err := c.buildAttrs(route)
+		if err != nil {
+			t.Fatalf("%v: expected attrs, got error: %v", version, err)
+		}
+
+		if _, ok := attrs[name[1]]; ok || attrs[name[0]] != "vpn" {
+			t.Errorf("%v: expected `%v` attribute, got %v", version, name[0], attrs)
+		}
+	}
+
+	res, err := setFieldsFromMap(&ResourceIPRoute{}, map[string]string{
+		"dst-address":  "10.0.0.0/8",
+		"gateway":      "192.168.88.254",
+		"routing-mark": "vpn",
+		"active":       "true",
+	})
+	if err != nil {
+		t.Fatalf("expected fields set, got error: %v", err)
+	}
+
+	if r := res.(*ResourceIPRoute); r.RoutingTable != "vpn" || !r.Active {
+		t.Errorf("expected routing-mark decoded, got %+v", r)
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	f := newFakeRouterOS(t, nil)
+	f.Version = "6.49.10 (long-term)"
+	c := getFakeClient(t, f)
+
+	if v := c.Version(); v != mustParseVersion(f.Version) {
+		t.Errorf("expected version %v, got %v", f.Version, v)
+	}
+}
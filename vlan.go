@@ -0,0 +1,172 @@
+package routerosclient
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	minVLANID = 1
+	maxVLANID = 4094
+)
+
+// parseVLANIDs parses a list of VLAN IDs and ranges of them, e.g. "10", "20-29".
+// Returns sorted unique IDs.
+func parseVLANIDs(list []string) ([]int, error) {
+	seen := make(map[int]bool)
+
+	for _, s := range list {
+		bounds := strings.SplitN(strings.TrimSpace(s), "-", 2)
+
+		first, err := parseVLANID(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+
+		last := first
+		if len(bounds) == 2 {
+			if last, err = parseVLANID(bounds[1]); err != nil {
+				return nil, err
+			}
+		}
+
+		if first > last {
+			return nil, fmt.Errorf("invalid VLAN range: %v", s)
+		}
+
+		for id := first; id <= last; id++ {
+			seen[id] = true
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil, fmt.Errorf("no VLAN IDs")
+	}
+
+	ids := make([]int, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids, nil
+}
+
+func parseVLANID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id < minVLANID || id > maxVLANID {
+		return 0, fmt.Errorf("invalid VLAN ID: %v, must be %v-%v", s, minVLANID, maxVLANID)
+	}
+
+	return id, nil
+}
+
+// formatVLANIDs formats sorted VLAN IDs collapsing consecutive ones into ranges.
+func formatVLANIDs(ids []int) []string {
+	var list []string
+
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 {
+			j++
+		}
+
+		if i == j {
+			list = append(list, strconv.Itoa(ids[i]))
+		} else {
+			list = append(list, fmt.Sprintf("%v-%v", ids[i], ids[j]))
+		}
+
+		i = j + 1
+	}
+
+	return list
+}
+
+// BridgePortVLANs describes VLAN membership of a bridge port. PVID is the
+// untagged VLAN of the port, zero means none. Tagged are VLAN IDs or ranges
+// of them. To make VLANs reachable from the router itself, describe the
+// bridge as a port tagged with them.
+type BridgePortVLANs struct {
+	Interface string
+	PVID      int
+	Tagged    []string
+}
+
+// BuildBridgeVLANs derives the bridge VLAN table from VLAN membership of
+// ports. VLANs having the same tagged and untagged ports are merged into
+// a single entry. Entries are ordered by their first VLAN ID.
+func BuildBridgeVLANs(bridge string, ports []BridgePortVLANs) ([]Resource, error) {
+	tagged := make(map[int][]string)
+	untagged := make(map[int][]string)
+
+	for _, p := range ports {
+		if p.Interface == "" {
+			return nil, fmt.Errorf("interface is required")
+		}
+
+		if p.PVID != 0 {
+			if _, err := parseVLANID(strconv.Itoa(p.PVID)); err != nil {
+				return nil, fmt.Errorf("%v: %v", p.Interface, err)
+			}
+			untagged[p.PVID] = append(untagged[p.PVID], p.Interface)
+		}
+
+		if len(p.Tagged) == 0 {
+			continue
+		}
+
+		ids, err := parseVLANIDs(p.Tagged)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", p.Interface, err)
+		}
+
+		for _, id := range ids {
+			if id == p.PVID {
+				return nil, fmt.Errorf("%v: VLAN %v is both tagged and untagged", p.Interface, id)
+			}
+			tagged[id] = append(tagged[id], p.Interface)
+		}
+	}
+
+	var ids []int
+	for id := range tagged {
+		ids = append(ids, id)
+	}
+	for id := range untagged {
+		if _, ok := tagged[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	var groups []string
+	members := make(map[string][]int)
+
+	for _, id := range ids {
+		sort.Strings(tagged[id])
+		sort.Strings(untagged[id])
+
+		g := strings.Join(tagged[id], ",") + "|" + strings.Join(untagged[id], ",")
+		if _, ok := members[g]; !ok {
+			groups = append(groups, g)
+		}
+		members[g] = append(members[g], id)
+	}
+
+	var res []Resource
+	for _, g := range groups {
+		ids := members[g]
+
+		res = append(res, &ResourceInterfaceBridgeVLAN{
+			Bridge:   bridge,
+			Tagged:   tagged[ids[0]],
+			Untagged: untagged[ids[0]],
+			VLANIDs:  formatVLANIDs(ids),
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,85 @@
+package routerosclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVLANIDs(t *testing.T) {
+	ids, err := parseVLANIDs([]string{"20-22", "10", "21", "4094"})
+	if err != nil {
+		t.Fatalf("expected VLAN IDs parsed, got error: %v", err)
+	}
+
+	if expected := []int{10, 20, 21, 22, 4094}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("expected %v, got %v", expected, ids)
+	}
+
+	if list := formatVLANIDs(ids); !reflect.DeepEqual(list, []string{"10", "20-22", "4094"}) {
+		t.Errorf("unexpected formatted VLAN IDs: %v", list)
+	}
+
+	for _, invalid := range [][]string{nil, {"0"}, {"4095"}, {"30-20"}, {"10-x"}, {"ten"}} {
+		if _, err := parseVLANIDs(invalid); err == nil {
+			t.Errorf("%v: expected error, got nil", invalid)
+		}
+	}
+}
+
+func TestValidateBridgeVLAN(t *testing.T) {
+	invalid := []*ResourceInterfaceBridgeVLAN{
+		{Bridge: "br0"},
+		{Bridge: "br0", VLANIDs: []string{"1-5000"}},
+		{Bridge: "br0", VLANIDs: []string{"10"}, Tagged: []string{"ether2"}, Untagged: []string{"ether2"}},
+	}
+
+	for _, v := range invalid {
+		if err := v.validate(); err == nil {
+			t.Errorf("expected error for %+v, got nil", v)
+		}
+	}
+}
+
+func TestBuildBridgeVLANs(t *testing.T) {
+	res, err := BuildBridgeVLANs("br0", []BridgePortVLANs{
+		{Interface: "br0", Tagged: []string{"10", "20-21"}},
+		{Interface: "ether1", Tagged: []string{"10", "20-21"}},
+		{Interface: "ether2", PVID: 10},
+		{Interface: "ether3", PVID: 20},
+		{Interface: "ether4", PVID: 21},
+		{Interface: "ether5", PVID: 30},
+	})
+	if err != nil {
+		t.Fatalf("expected table built, got error: %v", err)
+	}
+
+	expected := []Resource{
+		&ResourceInterfaceBridgeVLAN{Bridge: "br0", Tagged: []string{"br0", "ether1"}, Untagged: []string{"ether2"}, VLANIDs: []string{"10"}},
+		&ResourceInterfaceBridgeVLAN{Bridge: "br0", Tagged: []string{"br0", "ether1"}, Untagged: []string{"ether3"}, VLANIDs: []string{"20"}},
+		&ResourceInterfaceBridgeVLAN{Bridge: "br0", Tagged: []string{"br0", "ether1"}, Untagged: []string{"ether4"}, VLANIDs: []string{"21"}},
+		&ResourceInterfaceBridgeVLAN{Bridge: "br0", Untagged: []string{"ether5"}, VLANIDs: []string{"30"}},
+	}
+
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("unexpected table:")
+		for _, r := range res {
+			t.Logf("%+v", r)
+		}
+	}
+
+	res, err = BuildBridgeVLANs("br0", []BridgePortVLANs{
+		{Interface: "br0", Tagged: []string{"100-102"}},
+		{Interface: "ether1", Tagged: []string{"100-102"}},
+	})
+	if err != nil {
+		t.Fatalf("expected table built, got error: %v", err)
+	}
+
+	if len(res) != 1 || !reflect.DeepEqual(res[0].(*ResourceInterfaceBridgeVLAN).VLANIDs, []string{"100-102"}) {
+		t.Errorf("expected VLANs merged into a single entry, got %+v", res)
+	}
+
+	if _, err := BuildBridgeVLANs("br0", []BridgePortVLANs{{Interface: "ether1", PVID: 10, Tagged: []string{"10"}}}); err == nil {
+		t.Errorf("expected error for VLAN both tagged and untagged, got nil")
+	}
+}
@@ -0,0 +1,205 @@
+package routerosclient
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/go-routeros/routeros"
+	"github.com/go-routeros/routeros/proto"
+)
+
+// EventType is a type of change reported by Watch.
+type EventType string
+
+const (
+	EventAdded   EventType = "add"
+	EventChanged EventType = "change"
+	EventRemoved EventType = "remove"
+)
+
+// Event describes a change of a resource. For removed resources only ID is known,
+// so Resource has nothing but ID set.
+type Event struct {
+	Type     EventType
+	ID       string
+	Resource Resource
+}
+
+// listener is implemented by connections supporting streaming commands,
+// e.g. *routeros.Client.
+type listener interface {
+	ListenArgs([]string) (*routeros.ListenReply, error)
+}
+
+// Watcher streams changes of resources of a given type.
+type Watcher struct {
+	c      *Client
+	l      *routeros.ListenReply
+	events chan Event
+	done   chan struct{} // closed by Cancel
+	once   sync.Once
+	err    error
+}
+
+// Watch streams changes of all resources of the same type as res, using
+// `listen` command of the menu. Watching stops when ctx is done or Cancel is called.
+// Note: connection is switched to async mode, since listen requires it.
+func (c *Client) Watch(ctx context.Context, res Resource) (*Watcher, error) {
+	log.Printf("[D][W] Watch(%v)", res)
+
+	conn, ok := c.conn.(listener)
+	if !ok {
+		return nil, fmt.Errorf("connection does not support listen command")
+	}
+
+	known, err := c.getResourceIDs(res)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := getResourceMenu(res) + "/listen"
+	log.Printf("[D][W][->] %v", cmd)
+
+	c.mu.Lock()
+	l, err := conn.ListenArgs([]string{cmd})
+	c.mu.Unlock()
+
+	if err != nil {
+		log.Printf("[E][W][<-] error: %v", err)
+		return nil, err
+	}
+
+	w := &Watcher{
+		c:      c,
+		l:      l,
+		events: make(chan Event),
+		done:   make(chan struct{}),
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Cancel()
+		case <-stop:
+		}
+	}()
+
+	go func() {
+		defer close(w.events)
+		defer close(stop)
+
+		for sen := range l.Chan() {
+			log.Printf("[D][W][<-] %v", sen)
+
+			ev, err := decodeEvent(res, sen, known)
+			if err != nil {
+				log.Printf("[E][W] unable to decode event: %v", err)
+				continue
+			}
+
+			// after cancellation events are dropped, but sentences are
+			// drained until RouterOS confirms it, so the connection
+			// isn't stalled by a consumer which stopped reading
+			select {
+			case w.events <- ev:
+			case <-w.done:
+			case <-ctx.Done():
+			}
+		}
+
+		w.err = l.Err()
+	}()
+
+	return w, nil
+}
+
+// Events returns the channel of events. It's closed when watching stops.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+// Cancel stops watching by sending /cancel for the listen command.
+func (w *Watcher) Cancel() error {
+	var err error
+
+	w.once.Do(func() {
+		close(w.done)
+
+		log.Printf("[D][W][->] /cancel")
+
+		w.c.mu.Lock()
+		_, err = w.l.Cancel()
+		w.c.mu.Unlock()
+
+		if err != nil {
+			log.Printf("[E][W][<-] error: %v", err)
+		}
+	})
+
+	return err
+}
+
+// Err returns the error which terminated watching, if any.
+// It must be called after the events channel is closed.
+func (w *Watcher) Err() error {
+	return w.err
+}
+
+func decodeEvent(res Resource, sen *proto.Sentence, known map[string]bool) (Event, error) {
+	id := sen.Map[".id"]
+	if id == "" {
+		return Event{}, fmt.Errorf("no id in sentence: %v", sen)
+	}
+
+	nr, err := newResource(res)
+	if err != nil {
+		return Event{}, err
+	}
+
+	if sen.Map[".dead"] == "true" {
+		delete(known, id)
+		nr.setID(id)
+
+		return Event{Type: EventRemoved, ID: id, Resource: nr}, nil
+	}
+
+	if _, err := setFieldsFromMap(nr, sen.Map); err != nil {
+		return Event{}, err
+	}
+
+	ev := Event{Type: EventAdded, ID: id, Resource: nr}
+	if known[id] {
+		ev.Type = EventChanged
+	}
+	known[id] = true
+
+	return ev, nil
+}
+
+// getResourceIDs returns ids of all resources of the same type as res.
+func (c *Client) getResourceIDs(res Resource) (map[string]bool, error) {
+	proplist := []string{".id"}
+
+	cmd, err := buildCommand(res.getReadCommand(), &proplist, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[D][W][->] %v", cmd)
+
+	r, err := c.Run(cmd)
+	if err != nil {
+		log.Printf("[E][W][<-] error: %v", err)
+		return nil, err
+	}
+	log.Printf("[D][W][<-] %v | %v", r.Re, r.Done)
+
+	ids := make(map[string]bool, len(r.Re))
+	for _, re := range r.Re {
+		ids[re.Map[".id"]] = true
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,136 @@
+package routerosclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-routeros/routeros"
+)
+
+// newFakeLeaseRouter returns RouterOS streaming three lease events on listen.
+func newFakeLeaseRouter(t *testing.T) *FakeRouterOS {
+	var mu sync.Mutex
+	listens := make(map[string]*FakeReply)
+
+	return newFakeRouterOS(t, func(r *FakeReply, words []string) {
+		switch words[0] {
+		case "/ip/dhcp-server/lease/print":
+			r.Re(map[string]string{".id": "*1"})
+			r.Done(nil)
+		case "/ip/dhcp-server/lease/listen":
+			mu.Lock()
+			listens[r.tag] = r
+			mu.Unlock()
+
+			go func() {
+				r.Re(map[string]string{".id": "*1", "address": "192.168.0.10", "mac-address": "00:11:22:33:44:55", "server": "dhcp1"})
+				r.Re(map[string]string{".id": "*2", "address": "192.168.0.11", "mac-address": "00:11:22:33:44:56", "server": "dhcp1"})
+				r.Re(map[string]string{".id": "*1", ".dead": "true"})
+			}()
+		case "/cancel":
+			mu.Lock()
+			l := listens[words[1][len("=tag="):]]
+			mu.Unlock()
+
+			if l != nil {
+				l.Interrupted()
+			}
+			r.Done(nil)
+		default:
+			r.Done(nil)
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	f := newFakeLeaseRouter(t)
+	c := getFakeClient(t, f)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := c.Watch(ctx, &ResourceDHCPServerLease{})
+	if err != nil {
+		t.Fatalf("expected to watch, got error: %v", err)
+	}
+
+	expected := []struct {
+		typ EventType
+		id  string
+	}{
+		{EventChanged, "*1"},
+		{EventAdded, "*2"},
+		{EventRemoved, "*1"},
+	}
+
+	for _, e := range expected {
+		select {
+		case ev := <-w.Events():
+			if ev.Type != e.typ || ev.ID != e.id {
+				t.Errorf("expected %v of %v, got %v of %v", e.typ, e.id, ev.Type, ev.ID)
+			}
+			if ev.Type == EventAdded {
+				lease := ev.Resource.(*ResourceDHCPServerLease)
+				if lease.Address != "192.168.0.11" {
+					t.Errorf("expected lease decoded, got %v", lease)
+				}
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout exceeded waiting for %v event", e.typ)
+		}
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-w.Events():
+		if ok {
+			t.Errorf("expected no more events")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timeout exceeded waiting for watcher to stop")
+	}
+
+	if err := w.Err(); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+}
+
+func TestWatchCancelUnread(t *testing.T) {
+	f := newFakeLeaseRouter(t)
+	c := getFakeClient(t, f)
+
+	w, err := c.Watch(context.Background(), &ResourceDHCPServerLease{})
+	if err != nil {
+		t.Fatalf("expected to watch, got error: %v", err)
+	}
+
+	// let events pile up with nobody reading them
+	time.Sleep(50 * time.Millisecond)
+
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- w.Cancel() }()
+
+	select {
+	case err := <-cancelled:
+		if err != nil {
+			t.Errorf("expected watch cancelled, got error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timeout exceeded waiting for cancel")
+	}
+
+	if _, err := c.Run("/ip/dhcp-server/lease/print"); err != nil {
+		t.Errorf("expected connection usable after cancel, got error: %v", err)
+	}
+}
+
+func TestWatchNotSupported(t *testing.T) {
+	c := &Client{conn: &ConnStub{q: make(chan *routeros.Reply, 1)}}
+
+	if _, err := c.Watch(context.Background(), &ResourceDHCPServerLease{}); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}